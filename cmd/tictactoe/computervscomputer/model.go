@@ -5,6 +5,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"tictactoe/cmd/tictactoe/pkg/choices"
 	"tictactoe/cmd/tictactoe/pkg/computerstrategy"
+	"tictactoe/cmd/tictactoe/pkg/explanation"
+	"tictactoe/cmd/tictactoe/pkg/savedgames"
+	"tictactoe/cmd/tictactoe/review"
 
 	"tictactoe/domain/computer"
 	"tictactoe/domain/game"
@@ -29,6 +32,11 @@ type Model struct {
 	computer1              game.Player
 	computer2              game.Player
 	timer                  timer.Model
+	status                 string
+	// explanation explains the last move if the strategy explains its choices.
+	explanation string
+	// review is the post-game review shown instead of the game, nil means the game is shown.
+	review *review.Model
 }
 
 // NewModel creates a new computer vs computer model.
@@ -40,8 +48,23 @@ func NewModel() Model {
 	}
 }
 
+// NewModelFromGame creates a new computer vs computer model that continues the game.
+// The returned command starts the game.
+func NewModelFromGame(g *game.Game) (Model, tea.Cmd) {
+	m := Model{
+		timer:       timer.NewWithInterval(time.Minute*5, 500*time.Millisecond),
+		currentView: viewTypeGame,
+		game:        g,
+	}
+	m.computer1, m.computer2 = g.Players()
+	return m, m.timer.Start()
+}
+
 // Update updates a computer vs computer  model.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.review != nil {
+		return m.updateReview(msg)
+	}
 	switch msg := msg.(type) {
 	// Computer wil play its turn when the timer ticks
 	case timer.TickMsg:
@@ -51,13 +74,35 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 		p := m.game.CurrentTurnPlayer().(computer.Player)
-		m.game.MustPlay(p.GetNextCell(m.game.GetBoard()))
+		move, e := p.GetNextMoveWithExplanation(m.game.GetBoard())
+		m.game.MustPlayMove(move)
+		m.explanation = ""
+		if e != nil {
+			m.explanation = explanation.Sprint(p.Name(), *e)
+		}
 		m.timer, cmd = m.timer.Update(msg)
 		return m, cmd
 	case timer.StartStopMsg:
 		var cmd tea.Cmd
 		m.timer, cmd = m.timer.Update(msg)
 		return m, cmd
+	case tea.KeyMsg:
+		// save the game to a file
+		if msg.String() == "s" && m.currentView == viewTypeGame {
+			path, err := savedgames.Save(m.game)
+			if err != nil {
+				m.status = "Error: " + err.Error()
+				return m, nil
+			}
+			m.status = "Game saved to " + path
+			return m, nil
+		}
+		// review the finished game
+		if msg.String() == "v" && m.currentView == viewTypeGame && m.game.IsOver() {
+			r, cmd := review.NewModel(m.game)
+			m.review = &r
+			return m, cmd
+		}
 	}
 	switch m.currentView {
 	case viewTypeComputer1StrategySelection:
@@ -84,13 +129,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateReview passes the message to the review model, esc returns to the game.
+func (m Model) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "esc" {
+		m.review = nil
+		return m, nil
+	}
+	child, cmd := m.review.Update(msg)
+	r := child.(review.Model)
+	m.review = &r
+	return m, cmd
+}
+
 // View returns a computer vs computer model view.
 func (m Model) View() string {
+	if m.review != nil {
+		return m.review.View()
+	}
 	switch m.currentView {
 	case viewTypeComputer1StrategySelection, viewTypeComputer2StrategySelection:
 		return m.computerStrategyModel.View()
 	case viewTypeGame:
-		return m.game.Sprint(nil)
+		help := "\nPress s to save the game."
+		if m.game.IsOver() {
+			help = "\nPress s to save the game, v to review it."
+		}
+		return m.game.Sprint(nil) + "\n" + m.explanation + "\n" + m.status + help
 	}
 	return ""
 }
@@ -1,71 +1,156 @@
 package game
 
 import (
+	"errors"
+	"strings"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 
+	"tictactoe/cmd/tictactoe/pkg/explanation"
+	"tictactoe/cmd/tictactoe/pkg/savedgames"
+	"tictactoe/cmd/tictactoe/review"
+	"tictactoe/domain/analysis"
 	"tictactoe/domain/board"
 	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/mcts"
 	"tictactoe/domain/game"
+	"tictactoe/domain/hint"
+	"tictactoe/domain/rules"
 
 	"fmt"
 )
 
+const (
+	// hintTimeBudget limits the time of a hint on positions too big for the perfect-play analyzer.
+	hintTimeBudget = time.Second
+	// clockTick is the interval the clocks of a timed game are refreshed at.
+	clockTick = 100 * time.Millisecond
+	// computerClockMargin is the time a computer keeps on its clock to play the chosen move.
+	computerClockMargin = 50 * time.Millisecond
+)
+
 // Model is the game model.
 type Model struct {
-	game   game.Game
-	cursor *board.Cell
-	err    error
+	game      game.Game
+	cursor    *board.Cell
+	err       error
+	status    string
+	hint      *boardHint
+	thinking  bool
+	hintsUsed map[board.CellValue]int
+	// explanation explains the last computer move if its strategy explains its choices.
+	explanation string
+	// review is the post-game review shown instead of the game, nil means the game is shown.
+	review *review.Model
+	// mark is the mark placed by human players in the wild variant.
+	mark board.CellValue
+}
+
+// boardHint is the hint for the board, it is shown until the board changes.
+type boardHint struct {
+	hint.Hint
+	board board.Board
+}
+
+// tickMsg refreshes the clocks of a timed game.
+type tickMsg time.Time
+
+// tick schedules the next refresh of the clocks.
+func tick() tea.Cmd {
+	return tea.Tick(clockTick, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+// hintMsg is the computed hint.
+type hintMsg struct {
+	hint boardHint
+	err  error
 }
 
 // NewModel creates a new game model.
 func NewModel(game game.Game) Model {
+	m := Model{
+		game:      game,
+		hintsUsed: map[board.CellValue]int{},
+		mark:      board.XValue,
+	}
 	// Play computer turn if it's the first player
 	if p, ok := game.CurrentTurnPlayer().(computer.Player); ok {
-		game.MustPlay(p.GetNextCell(game.GetBoard()))
-	}
-	cursor := game.GetBoard().FindFirstEmptyCell()
-
-	return Model{
-		game:   game,
-		cursor: cursor,
+		m.playComputerTurn(p)
 	}
+	m.cursor = m.game.GetBoard().FindFirstEmptyCell()
+	m.dropCursor()
+	return m
 }
 
 // Update handles messages from the Bubble Tea runtime.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	boardSize := len(m.game.GetBoard())
+	if _, ok := msg.(tickMsg); ok {
+		// The clocks stop ticking when the game is over, e.g. when a flag falls.
+		if m.game.Clock() == nil || m.game.CheckFlag() || m.game.IsOver() {
+			return m, nil
+		}
+		return m, tick()
+	}
+	if m.review != nil {
+		return m.updateReview(msg)
+	}
+	b := m.game.GetBoard()
+	// In the gravity variant the cursor moves over the columns only.
+	gravity := b.Variant() == board.Gravity
 	m.err = nil
+	m.status = ""
 	switch msg := msg.(type) {
+	case hintMsg:
+		m.thinking = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		h := msg.hint
+		m.hint = &h
 
 	case tea.KeyMsg:
 		switch msg.String() {
 		// move the cursor up to the next empty cell
 		case "up", "k":
-			if m.cursor.RowNumber > 0 {
+			if m.cursor.RowNumber > 0 && !gravity {
 				m.cursor.RowNumber--
 			}
 
 		// move the cursor down to the next empty cell
 		case "down", "j":
-			if m.cursor.RowNumber < boardSize-1 {
+			if m.cursor.RowNumber < b.Rows()-1 && !gravity {
 				m.cursor.RowNumber++
 			}
 		// move the cursor left to the next empty cell
 		case "left", "h":
 			if m.cursor.ColumnNumber > 0 {
 				m.cursor.ColumnNumber--
+				m.dropCursor()
 			}
 		// move the cursor right to the next empty cell
 		case "right", "l":
-			if m.cursor.ColumnNumber < boardSize-1 {
+			if m.cursor.ColumnNumber < b.Columns()-1 {
 				m.cursor.ColumnNumber++
+				m.dropCursor()
 			}
 		// play the current turn player
 		case "enter":
 			if m.game.IsOver() {
 				return m, nil
 			}
-			err := m.game.Play(*m.cursor)
+			var err error
+			switch b.Variant() {
+			case board.Gravity:
+				err = m.game.PlayColumn(m.cursor.ColumnNumber)
+			case board.Wild:
+				err = m.game.PlayMove(rules.Move{Cell: *m.cursor, Mark: m.mark})
+			default:
+				err = m.game.Play(*m.cursor)
+			}
 			if err != nil {
 				m.err = err
 				return m, nil
@@ -73,31 +158,238 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// Play computer turn
 			if p, ok := m.game.CurrentTurnPlayer().(computer.Player); ok && !m.game.IsOver() {
-				m.game.MustPlay(p.GetNextCell(m.game.GetBoard()))
+				m.playComputerTurn(p)
+			}
+			m.moveCursorToFirstEmptyCell()
+		// toggle the mark placed in the wild variant
+		case "t":
+			if b.Variant() == board.Wild {
+				m.mark = -m.mark
 			}
-			// Move the cursor to the first empty cell
-			emptyCell := m.game.GetBoard().FindFirstEmptyCell()
-			if emptyCell != nil {
-				m.cursor = emptyCell
+		// take back the last human move and the computer reply to it
+		case "u":
+			m.explanation = ""
+			m.err = m.undo()
+			m.moveCursorToFirstEmptyCell()
+		// play again the last undone human move and the computer reply to it
+		case "r":
+			m.explanation = ""
+			m.err = m.redo()
+			m.moveCursorToFirstEmptyCell()
+		// suggest the best move for the current human player
+		case "?":
+			if m.game.IsOver() || m.isComputerTurn() || m.thinking {
+				return m, nil
+			}
+			m.hintsUsed[m.game.GetBoard().CurrentTurnCellValue()]++
+			m.thinking = true
+			return m, requestHint(m.game.GetBoard())
+		// review the finished game
+		case "v":
+			if !m.game.IsOver() {
+				return m, nil
+			}
+			r, cmd := review.NewModel(&m.game)
+			m.review = &r
+			return m, cmd
+		// save the game to a file
+		case "s":
+			path, err := savedgames.Save(&m.game)
+			if err != nil {
+				m.err = err
+				return m, nil
 			}
+			m.status = "Game saved to " + path
 		}
 	}
 
 	return m, nil
 }
 
+// updateReview passes the message to the review model, esc returns to the game.
+func (m Model) updateReview(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok && msg.String() == "esc" {
+		m.review = nil
+		return m, nil
+	}
+	child, cmd := m.review.Update(msg)
+	r := child.(review.Model)
+	m.review = &r
+	return m, cmd
+}
+
+// undo takes back moves until it is a human turn again.
+// If only computer moves were played, nothing is taken back.
+func (m *Model) undo() error {
+	undone := 0
+	for {
+		if err := m.game.Undo(); err != nil {
+			// There is no human move to take back, so restore the undone computer moves.
+			for ; undone > 0; undone-- {
+				if err := m.game.Redo(); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+		undone++
+		if !m.isComputerTurn() {
+			return nil
+		}
+	}
+}
+
+// redo plays again undone moves until it is a human turn again.
+func (m *Model) redo() error {
+	if err := m.game.Redo(); err != nil {
+		return err
+	}
+	for m.isComputerTurn() && !m.game.IsOver() {
+		if !m.game.CanRedo() {
+			// The computer reply was not undone, so the computer plays it again.
+			m.playComputerTurn(m.game.CurrentTurnPlayer().(computer.Player))
+			return nil
+		}
+		if err := m.game.Redo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// playComputerTurn plays the computer turn and remembers the explanation of the move.
+// In a timed game the computer chooses the move before its flag falls.
+func (m *Model) playComputerTurn(p computer.Player) {
+	if c := m.game.Clock(); c != nil {
+		p = p.WithDeadline(c.Deadline().Add(-computerClockMargin))
+	}
+	move, e := p.GetNextMoveWithExplanation(m.game.GetBoard())
+	if err := m.game.PlayMove(move); err != nil {
+		m.err = err
+		return
+	}
+	m.explanation = ""
+	if e != nil {
+		m.explanation = explanation.Sprint(p.Name(), *e)
+	}
+}
+
+// requestHint computes the hint for the board in the background.
+func requestHint(b board.Board) tea.Cmd {
+	return func() tea.Msg {
+		h, err := hint.FromAnalysis(b)
+		if errors.Is(err, analysis.ErrTooManyEmptyCells) {
+			h, err = hint.FromStrategy(b, mcts.NewStrategy(mcts.WithTimeBudget(hintTimeBudget))), nil
+		}
+		return hintMsg{hint: boardHint{Hint: h, board: b}, err: err}
+	}
+}
+
+// currentHint returns the hint if it is still valid for the board.
+func (m Model) currentHint() *boardHint {
+	if m.hint == nil || m.hint.board != m.game.GetBoard() {
+		return nil
+	}
+	return m.hint
+}
+
+// hintsSummary returns the count of hints used by every human player.
+func (m Model) hintsSummary() string {
+	player1, player2 := m.game.Players()
+	var counts []string
+	for _, p := range []struct {
+		player game.Player
+		value  board.CellValue
+	}{{player1, board.XValue}, {player2, board.OValue}} {
+		if _, ok := p.player.(computer.Player); ok {
+			continue
+		}
+		counts = append(counts, fmt.Sprintf("%s: %d", p.player.Name(), m.hintsUsed[p.value]))
+	}
+	return "Hints used: " + strings.Join(counts, ", ")
+}
+
+// isComputerTurn returns true if the current turn player is a computer.
+func (m *Model) isComputerTurn() bool {
+	_, ok := m.game.CurrentTurnPlayer().(computer.Player)
+	return ok
+}
+
+// moveCursorToFirstEmptyCell moves the cursor to the first empty cell,
+// in the gravity variant the cursor stays in its column.
+func (m *Model) moveCursorToFirstEmptyCell() {
+	if m.game.GetBoard().Variant() == board.Gravity {
+		m.dropCursor()
+		return
+	}
+	emptyCell := m.game.GetBoard().FindFirstEmptyCell()
+	if emptyCell != nil {
+		m.cursor = emptyCell
+	}
+}
+
+// dropCursor moves the cursor of the gravity variant to the cell the mark falls to in its column.
+// The cursor of a full column stays where it is.
+func (m *Model) dropCursor() {
+	if m.cursor == nil || m.game.GetBoard().Variant() != board.Gravity {
+		return
+	}
+	if cell, err := m.game.GetBoard().DropCell(m.cursor.ColumnNumber); err == nil {
+		m.cursor = &cell
+	}
+}
+
 // View renders the game model.
 func (m Model) View() string {
-	result := m.game.Sprint(m.cursor)
-	if m.err != nil {
+	if m.review != nil {
+		return m.review.View()
+	}
+	var hintCell *board.Cell
+	h := m.currentHint()
+	if h != nil {
+		hintCell = &h.Cell
+	}
+	result := m.game.SprintWithHint(m.cursor, hintCell)
+	if c := m.game.Clock(); c != nil {
+		result += "\nClock " + c.Control().String() + ": " + c.Sprint(time.Now())
+	}
+	switch m.game.GetBoard().Variant() {
+	case board.Misere:
+		result = "Misère: completing a line loses.\n" + result
+	case board.Gravity:
+		result = "Gravity: marks fall to the bottom, choose the column with left and right.\n" + result
+	case board.Wild:
+		result = fmt.Sprintf("Wild: place X or O, completing a line wins. Your mark: %s, press t to toggle.\n", m.mark) + result
+	}
+	switch {
+	case m.err != nil:
 		result += fmt.Sprintf("\nError: %s", m.err)
-	} else {
+	case m.status != "":
+		result += "\n" + m.status
+	case m.thinking:
+		result += "\nThinking about a hint..."
+	case h != nil && m.game.GetBoard().Variant() == board.Wild:
+		result += fmt.Sprintf("\nHint: place %s, %s", h.Mark, h.Reason)
+	case h != nil:
+		result += "\nHint: " + h.Reason
+	default:
 		result += "\n"
 	}
+	if m.explanation != "" {
+		result += "\n" + m.explanation
+	}
+	if m.game.IsOver() {
+		result += "\n" + m.hintsSummary()
+		result += "\nPress v to review the game."
+	}
+	result += "\nPress ? for a hint, u to undo, r to redo, s to save the game."
 	return result
 }
 
-// Init initializes the model before the game loop starts.
+// Init initializes the model before the game loop starts, the clocks of a timed game start ticking.
 func (m Model) Init() tea.Cmd {
+	if m.game.Clock() != nil {
+		return tick()
+	}
 	return nil
 }
@@ -4,9 +4,14 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"tictactoe/cmd/tictactoe/pkg/choices"
 	"tictactoe/cmd/tictactoe/pkg/computerstrategy"
+	"tictactoe/cmd/tictactoe/pkg/difficultylevel"
+	"tictactoe/cmd/tictactoe/pkg/timecontrol"
 
 	cmdGame "tictactoe/cmd/tictactoe/game"
+	"tictactoe/domain/board"
+	"tictactoe/domain/clock"
 	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/difficulty"
 	"tictactoe/domain/game"
 	"tictactoe/domain/player"
 )
@@ -15,7 +20,9 @@ type viewType int
 
 const (
 	viewTypeComputerStrategySelection viewType = iota + 1
+	viewTypeDifficultySelection
 	viewTypeChooseFirstPlayer
+	viewTypeTimeControlSelection
 	viewTypeGame
 )
 
@@ -23,17 +30,29 @@ const (
 type Model struct {
 	gameModel              cmdGame.Model
 	computerStrategyModel  choices.Model
+	difficultyModel        choices.Model
 	chooseFirstPlayerModel choices.Model
+	timeControlModel       choices.Model
 	currentView            viewType
+	strategy               computer.Strategy
 	computer               game.Player
 	player                 game.Player
+	firstPlayer            game.Player
+	board                  board.Board
 }
 
 // NewModel creates a new human vs computer model.
 func NewModel() Model {
+	return NewModelWithBoard(board.Board{})
+}
+
+// NewModelWithBoard creates a new human vs computer model of the game played on the board,
+// only the strategies that play the board variant are offered.
+func NewModelWithBoard(b board.Board) Model {
 	return Model{
 		currentView:           viewTypeComputerStrategySelection,
-		computerStrategyModel: computerstrategy.NewModel("Choose computer strategy:"),
+		computerStrategyModel: computerstrategy.NewModelForVariant("Choose computer strategy:", b.Variant()),
+		board:                 b,
 	}
 }
 
@@ -45,6 +64,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.computerStrategyModel = child.(choices.Model)
 		s, ok := m.computerStrategyModel.GetSelected().(computer.Strategy)
 		if ok {
+			m.strategy = s
+			m.difficultyModel = difficultylevel.NewModel("Choose difficulty:")
+			m.currentView = viewTypeDifficultySelection
+		}
+	case viewTypeDifficultySelection:
+		child, _ := m.difficultyModel.Update(msg)
+		m.difficultyModel = child.(choices.Model)
+		level, ok := m.difficultyModel.GetSelected().(difficulty.Level)
+		if ok {
+			s := m.strategy
+			if level != difficulty.Perfect {
+				s = difficulty.NewStrategy(s, level)
+			}
 			m.computer = computer.New(s)
 			m.player = player.MustNew("Player")
 			m.chooseFirstPlayerModel = chooseFirstPlayer(m.computer, m.player)
@@ -55,18 +87,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.chooseFirstPlayerModel = child.(choices.Model)
 		firstPlayer, ok := m.chooseFirstPlayerModel.GetSelected().(game.Player)
 		if ok {
-			p1 := firstPlayer
+			m.firstPlayer = firstPlayer
+			m.timeControlModel = timecontrol.NewModel("Choose time control:")
+			m.currentView = viewTypeTimeControlSelection
+		}
+	case viewTypeTimeControlSelection:
+		child, _ := m.timeControlModel.Update(msg)
+		m.timeControlModel = child.(choices.Model)
+		tc, ok := m.timeControlModel.GetSelected().(clock.TimeControl)
+		if ok {
+			p1 := m.firstPlayer
 			p2 := m.computer
 			if p1 == m.computer {
 				p2 = m.player
 			}
-			g := game.New(p1, p2)
+			g := game.NewWithBoard(m.board, p1, p2)
+			if tc != (clock.TimeControl{}) {
+				if err := g.StartClock(tc); err != nil {
+					panic(err)
+				}
+			}
 			m.gameModel = cmdGame.NewModel(*g)
 			m.currentView = viewTypeGame
+			return m, m.gameModel.Init()
 		}
 	case viewTypeGame:
-		child, _ := m.gameModel.Update(msg)
+		child, cmd := m.gameModel.Update(msg)
 		m.gameModel = child.(cmdGame.Model)
+		return m, cmd
 	}
 	return m, nil
 }
@@ -76,8 +124,12 @@ func (m Model) View() string {
 	switch m.currentView {
 	case viewTypeComputerStrategySelection:
 		return m.computerStrategyModel.View()
+	case viewTypeDifficultySelection:
+		return m.difficultyModel.View()
 	case viewTypeChooseFirstPlayer:
 		return m.chooseFirstPlayerModel.View()
+	case viewTypeTimeControlSelection:
+		return m.timeControlModel.View()
 	case viewTypeGame:
 		return m.gameModel.View()
 	}
@@ -1,35 +1,75 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"tictactoe/cmd/tictactoe/computervscomputer"
 	cmdGame "tictactoe/cmd/tictactoe/game"
 	"tictactoe/cmd/tictactoe/humanvscomputer"
+	"tictactoe/cmd/tictactoe/network"
 	"tictactoe/cmd/tictactoe/pkg/choices"
 	"tictactoe/cmd/tictactoe/pkg/mode"
+	"tictactoe/cmd/tictactoe/pkg/savedgames"
+	"tictactoe/cmd/tictactoe/serve"
+	"tictactoe/cmd/tictactoe/simulate"
+	"tictactoe/cmd/tictactoe/tablebase"
+	"tictactoe/cmd/tictactoe/ultimate"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
 	"tictactoe/domain/game"
 	"tictactoe/domain/player"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	p := tea.NewProgram(newMainModel())
 	if _, err := p.Run(); err != nil {
 		panic(err)
 	}
 }
 
+// runCommand runs the non-interactive command with the arguments.
+func runCommand(name string, args []string) error {
+	switch name {
+	case "simulate":
+		return simulate.Run(args, os.Stdout)
+	case "host":
+		return network.Host(args, os.Stdout)
+	case "join":
+		return network.Join(args, os.Stdout)
+	case "serve":
+		return serve.Run(args, os.Stdout)
+	case "tablebase":
+		return tablebase.Run(args, os.Stdout)
+	}
+	return fmt.Errorf("unknown command %q, available commands: simulate, host, join, serve, tablebase", name)
+}
+
 type viewType int
 
 const (
 	viewTypeModeSelection viewType = iota
+	viewTypeLoadGame
 	viewTypeGame
 )
 
 type mainModel struct {
 	chooseGameModeModel choices.Model
+	loadGameModel       choices.Model
 	gameModel           tea.Model
 	currentView         viewType
+	err                 error
 }
 
 func newMainModel() mainModel {
@@ -67,9 +107,37 @@ func (m mainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.gameModel = humanvscomputer.NewModel()
 		case mode.ComputerVsComputer:
 			m.gameModel = computervscomputer.NewModel()
+		case mode.Misere:
+			m.gameModel = humanvscomputer.NewModelWithBoard(board.Board{}.WithVariant(board.Misere))
+		case mode.ConnectFour:
+			m.gameModel = humanvscomputer.NewModelWithBoard(board.MustNewRectangular(6, 7, 4).WithVariant(board.Gravity))
+		case mode.Wild:
+			m.gameModel = humanvscomputer.NewModelWithBoard(board.Board{}.WithVariant(board.Wild))
+		case mode.Ultimate:
+			m.gameModel = ultimate.NewModel()
+		case mode.LoadGame:
+			m.loadGameModel, m.err = savedgames.NewModel("Choose a saved game:")
+			m.currentView = viewTypeLoadGame
+			return m, nil
 		}
 
 		m.currentView = viewTypeGame
+	case viewTypeLoadGame:
+		child, _ := m.loadGameModel.Update(msg)
+		m.loadGameModel = child.(choices.Model)
+		path, ok := m.loadGameModel.GetSelected().(string)
+		if !ok {
+			return m, nil
+		}
+		g, err := savedgames.Load(path)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.gameModel, cmd = newLoadedGameModel(g)
+		m.currentView = viewTypeGame
+		return m, cmd
 	case viewTypeGame:
 		child, cmd := m.gameModel.Update(msg)
 		m.gameModel = child.(tea.Model)
@@ -86,6 +154,11 @@ func (m mainModel) View() string {
 	switch m.currentView {
 	case viewTypeModeSelection:
 		content = m.chooseGameModeModel.View()
+	case viewTypeLoadGame:
+		content = m.loadGameModel.View()
+		if m.err != nil {
+			content += "\nError: " + m.err.Error()
+		}
 	case viewTypeGame:
 		content = m.gameModel.View()
 
@@ -93,6 +166,17 @@ func (m mainModel) View() string {
 	return header + content + footer
 }
 
+// newLoadedGameModel creates a model to continue the loaded game.
+func newLoadedGameModel(g *game.Game) (tea.Model, tea.Cmd) {
+	player1, player2 := g.Players()
+	_, computer1 := player1.(computer.Player)
+	_, computer2 := player2.(computer.Player)
+	if computer1 && computer2 {
+		return computervscomputer.NewModelFromGame(g)
+	}
+	return cmdGame.NewModel(*g), nil
+}
+
 // Init initializes a main model.
 func (m mainModel) Init() tea.Cmd {
 	return nil
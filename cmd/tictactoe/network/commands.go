@@ -0,0 +1,80 @@
+// Package network implements the commands and the model of human vs human games over the network.
+package network
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/netplay"
+)
+
+// Host runs the host command: starts the game server and joins it as the first player.
+func Host(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("host", flag.ContinueOnError)
+	fs.SetOutput(out)
+	port := fs.Int("port", 7777, "TCP port to listen on")
+	name := fs.String("name", "Player 1", "player name")
+	size := fs.Int("size", board.DefaultSize, "board size")
+	winLength := fs.Int("win", board.DefaultSize, "count of cells in a row to win")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	b, err := board.New(*size, *winLength)
+	if err != nil {
+		return err
+	}
+
+	l, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(*port)))
+	if err != nil {
+		return err
+	}
+	s := netplay.NewServer(b)
+	defer s.Close()
+	go func() {
+		_ = s.Serve(l)
+	}()
+
+	client, err := netplay.Dial(net.JoinHostPort("127.0.0.1", strconv.Itoa(*port)), *name)
+	if err != nil {
+		return err
+	}
+	return play(client)
+}
+
+// Join runs the join command: connects to the game server at host:port.
+func Join(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("join", flag.ContinueOnError)
+	fs.SetOutput(out)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: tictactoe join [flags] host:port")
+		fs.PrintDefaults()
+	}
+	name := fs.String("name", "Player 2", "player name")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("server address host:port is required")
+	}
+
+	client, err := netplay.Dial(fs.Arg(0), *name)
+	if err != nil {
+		return err
+	}
+	return play(client)
+}
+
+// play runs the game model until the player quits.
+func play(client *netplay.Client) error {
+	defer client.Close()
+	_, err := tea.NewProgram(NewModel(client)).Run()
+	return err
+}
@@ -0,0 +1,223 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/netplay"
+	"tictactoe/domain/record"
+)
+
+// chatLines is the count of the last chat messages shown under the board.
+const chatLines = 5
+
+// Model is the model of a game played over the network.
+type Model struct {
+	client    *netplay.Client
+	state     netplay.State
+	names     map[board.CellValue]string
+	cursor    board.Cell
+	chat      []string
+	chatting  bool
+	chatInput string
+	status    string
+	err       error
+	closed    bool
+}
+
+// NewModel creates a new model of the game played by the client.
+func NewModel(client *netplay.Client) Model {
+	return Model{
+		client: client,
+		names:  map[board.CellValue]string{},
+	}
+}
+
+// eventMsg is the event received by the client.
+type eventMsg netplay.Event
+
+// waitForEvent waits for the next client event.
+func waitForEvent(client *netplay.Client) tea.Cmd {
+	return func() tea.Msg {
+		e, ok := <-client.Events()
+		if !ok {
+			return eventMsg{Kind: netplay.EventClosed}
+		}
+		return eventMsg(e)
+	}
+}
+
+// Init starts receiving the client events.
+func (m Model) Init() tea.Cmd {
+	return waitForEvent(m.client)
+}
+
+// Update handles messages from the Bubble Tea runtime.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case eventMsg:
+		m.handleEvent(netplay.Event(msg))
+		if m.closed {
+			return m, nil
+		}
+		return m, waitForEvent(m.client)
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			_ = m.client.Close()
+			return m, tea.Quit
+		}
+		if m.chatting {
+			m.updateChatInput(msg)
+			return m, nil
+		}
+		m.err = nil
+		m.status = ""
+		boardSize := m.state.Board.Size()
+		switch msg.String() {
+		case "q":
+			_ = m.client.Close()
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor.RowNumber > 0 {
+				m.cursor.RowNumber--
+			}
+		case "down", "j":
+			if m.cursor.RowNumber < boardSize-1 {
+				m.cursor.RowNumber++
+			}
+		case "left", "h":
+			if m.cursor.ColumnNumber > 0 {
+				m.cursor.ColumnNumber--
+			}
+		case "right", "l":
+			if m.cursor.ColumnNumber < boardSize-1 {
+				m.cursor.ColumnNumber++
+			}
+		// send the move, the server validates it and replies with the new state or an error
+		case "enter":
+			m.err = m.client.Move(m.cursor)
+		// give up the game
+		case "g":
+			m.err = m.client.Resign()
+		// start typing a chat message
+		case "c":
+			m.chatting = true
+			m.chatInput = ""
+		}
+	}
+	return m, nil
+}
+
+// updateChatInput edits the chat message, enter sends it and esc cancels it.
+func (m *Model) updateChatInput(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.chatting = false
+		if strings.TrimSpace(m.chatInput) != "" {
+			m.err = m.client.Chat(m.chatInput)
+		}
+	case tea.KeyEsc:
+		m.chatting = false
+	case tea.KeyBackspace:
+		if r := []rune(m.chatInput); len(r) > 0 {
+			m.chatInput = string(r[:len(r)-1])
+		}
+	case tea.KeySpace:
+		m.chatInput += " "
+	case tea.KeyRunes:
+		m.chatInput += string(msg.Runes)
+	}
+}
+
+// handleEvent applies the client event to the model.
+func (m *Model) handleEvent(e netplay.Event) {
+	switch e.Kind {
+	case netplay.EventState:
+		m.state = e.State
+		m.names[board.XValue] = e.State.Record.PlayerX.Name
+		m.names[board.OValue] = e.State.Record.PlayerO.Name
+		if !m.state.Board.Contains(m.cursor) || !m.state.Board.IsEmptyCell(m.cursor) {
+			if c := m.state.Board.FindFirstEmptyCell(); c != nil {
+				m.cursor = *c
+			}
+		}
+	case netplay.EventJoined:
+		m.names[e.Mark] = e.Text
+		m.status = fmt.Sprintf("%s has joined as %s", e.Text, e.Mark)
+	case netplay.EventLeft:
+		m.status = fmt.Sprintf("%s has disconnected, waiting for reconnection", m.playerName(e.Mark))
+	case netplay.EventResigned:
+		m.status = fmt.Sprintf("%s has resigned", m.playerName(e.Mark))
+	case netplay.EventChat:
+		m.chat = append(m.chat, fmt.Sprintf("%s: %s", m.playerName(e.Mark), e.Text))
+		if len(m.chat) > chatLines {
+			m.chat = m.chat[len(m.chat)-chatLines:]
+		}
+	case netplay.EventError:
+		m.err = fmt.Errorf("%s", e.Text)
+	case netplay.EventDisconnected:
+		m.status = "Connection is lost, reconnecting..."
+	case netplay.EventReconnected:
+		m.status = "Reconnected"
+	case netplay.EventClosed:
+		m.closed = true
+		m.status = "Disconnected"
+		if e.Text != "" {
+			m.status += ": " + e.Text
+		}
+	}
+}
+
+// playerName returns the name of the player with the mark.
+func (m *Model) playerName(mark board.CellValue) string {
+	if name := m.names[mark]; name != "" {
+		return name
+	}
+	return mark.String()
+}
+
+// View renders the model.
+func (m Model) View() string {
+	mark := m.client.Mark()
+	result := fmt.Sprintf("You play %s as %s\n\n", mark, m.playerName(mark))
+	result += m.state.Board.Sprint(&m.cursor)
+	result += "\n" + m.turnStatus() + "\n"
+	switch {
+	case m.err != nil:
+		result += fmt.Sprintf("Error: %s\n", m.err)
+	case m.status != "":
+		result += m.status + "\n"
+	}
+	if len(m.chat) > 0 {
+		result += "\n" + strings.Join(m.chat, "\n") + "\n"
+	}
+	if m.chatting {
+		result += "\nChat: " + m.chatInput + "_\nPress enter to send, esc to cancel."
+		return result
+	}
+	result += "\nPress enter to play, c to chat, g to resign, q to quit."
+	return result
+}
+
+// turnStatus returns the line describing whose turn it is or the result.
+func (m Model) turnStatus() string {
+	r := m.state.Record
+	switch {
+	case r.PlayerO.Name == "":
+		return "Waiting for the opponent to join..."
+	case r.Result == record.ResultXWins:
+		return fmt.Sprintf("%s wins", m.playerName(board.XValue))
+	case r.Result == record.ResultOWins:
+		return fmt.Sprintf("%s wins", m.playerName(board.OValue))
+	case r.Result == record.ResultDraw:
+		return "Draw"
+	}
+	turn := m.state.Board.CurrentTurnCellValue()
+	if turn == m.client.Mark() {
+		return "Your turn"
+	}
+	return fmt.Sprintf("%s's turn", m.playerName(turn))
+}
@@ -46,6 +46,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "enter", " ":
+			if len(m.choicesValues) == 0 {
+				return m, nil
+			}
 			m.selected = m.choicesValues[m.cursor]
 		}
 	}
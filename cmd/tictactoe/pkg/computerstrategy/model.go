@@ -1,25 +1,73 @@
 package computerstrategy
 
 import (
+	"strings"
+	"time"
+
 	"tictactoe/cmd/tictactoe/pkg/choices"
-	"tictactoe/domain/computer/strategies/minimax"
-	"tictactoe/domain/computer/strategies/modifiedwiki"
-	"tictactoe/domain/computer/strategies/wiki"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/difficulty"
+	// Register the built-in strategies.
+	_ "tictactoe/domain/computer/strategies"
 )
 
-// NewModel creates a new computer strategy model.
+// Factory creates a strategy, the seed is used by strategies that make random choices.
+type Factory func(seed int64) computer.Strategy
+
+// NewModel creates a new computer strategy model listing the registered strategies.
 func NewModel(questionTitle string) choices.Model {
-	return choices.NewModel(
-		[]string{
-			"Wiki",
-			"Modified Wiki",
-			"Minimax",
-		},
-		[]any{
-			wiki.NewStrategy(),
-			modifiedwiki.NewStrategy(),
-			minimax.NewStrategy(),
-		},
-		questionTitle,
-	)
+	return NewModelForVariant(questionTitle, board.Classic)
+}
+
+// NewModelForVariant creates a new computer strategy model listing the registered strategies that play the variant.
+func NewModelForVariant(questionTitle string, v board.Variant) choices.Model {
+	strategies := computer.StrategiesFor(v)
+	names := make([]string, 0, len(strategies))
+	values := make([]any, 0, len(strategies))
+	seed := time.Now().UnixNano()
+	for _, info := range strategies {
+		names = append(names, info.Name+" - "+info.Description)
+		values = append(values, info.New(computer.StrategyOptions{Seed: seed}))
+	}
+	return choices.NewModel(names, values, questionTitle)
+}
+
+// LookupFactory returns the factory of the registered strategy by its name, see computer.LookupStrategy.
+// The name may end with a difficulty level, e.g. "Minimax/Easy".
+func LookupFactory(name string) (Factory, error) {
+	if base, levelName, ok := strings.Cut(name, "/"); ok {
+		level, err := difficulty.ParseLevel(levelName)
+		if err != nil {
+			return nil, err
+		}
+		f, err := LookupFactory(base)
+		if err != nil {
+			return nil, err
+		}
+		return WithDifficulty(f, level), nil
+	}
+	info, err := computer.LookupStrategy(name)
+	if err != nil {
+		return nil, err
+	}
+	return func(seed int64) computer.Strategy {
+		return info.New(computer.StrategyOptions{Seed: seed})
+	}, nil
+}
+
+// WithDifficulty returns the factory of the strategy created by f and played at the level.
+func WithDifficulty(f Factory, level difficulty.Level) Factory {
+	return func(seed int64) computer.Strategy {
+		return difficulty.NewStrategy(f(seed), level, difficulty.WithSeed(seed+1))
+	}
+}
+
+// Lookup returns a new strategy by its name, see LookupFactory.
+func Lookup(name string) (computer.Strategy, error) {
+	f, err := LookupFactory(name)
+	if err != nil {
+		return nil, err
+	}
+	return f(time.Now().UnixNano()), nil
 }
@@ -0,0 +1,17 @@
+package difficultylevel
+
+import (
+	"tictactoe/cmd/tictactoe/pkg/choices"
+	"tictactoe/domain/computer/difficulty"
+)
+
+// NewModel creates a new difficulty level model, the selected value is a difficulty.Level.
+func NewModel(questionTitle string) choices.Model {
+	names := make([]string, 0, len(difficulty.Levels))
+	values := make([]any, 0, len(difficulty.Levels))
+	for _, l := range difficulty.Levels {
+		names = append(names, l.String())
+		values = append(values, l)
+	}
+	return choices.NewModel(names, values, questionTitle)
+}
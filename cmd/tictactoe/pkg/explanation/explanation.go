@@ -0,0 +1,21 @@
+// Package explanation renders the explanations of computer moves.
+package explanation
+
+import (
+	"fmt"
+	"strings"
+
+	"tictactoe/domain/computer"
+	"tictactoe/domain/record"
+)
+
+// Sprint returns the one-line explanation of the move of the player,
+// e.g. "Computer/Wiki played c2 by the rule Block, considered c2".
+func Sprint(player string, e computer.Explanation) string {
+	considered := make([]string, len(e.Considered))
+	for i, c := range e.Considered {
+		considered[i] = record.FormatCell(c)
+	}
+	return fmt.Sprintf("%s played %s by the rule %s, considered %s",
+		player, record.FormatCell(e.Cell), e.Rule, strings.Join(considered, ", "))
+}
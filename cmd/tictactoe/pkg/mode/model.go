@@ -14,12 +14,27 @@ const (
 	HumanVsComputer
 	// ComputerVsComputer represents the computer vs computer game Mode.
 	ComputerVsComputer
+	// LoadGame represents loading of a saved game.
+	LoadGame
+	// Ultimate represents the Ultimate tic-tac-toe game Mode.
+	Ultimate
+	// Misere represents the human vs computer game Mode where completing a line loses.
+	Misere
+	// ConnectFour represents the human vs computer game Mode on the 6x7 gravity board with four in a row to win.
+	ConnectFour
+	// Wild represents the human vs computer game Mode where either player may place X or O.
+	Wild
 )
 
 var modeNames = map[Mode]string{
 	HumanVsHuman:       "Human vs Human",
 	HumanVsComputer:    "Human vs Computer",
 	ComputerVsComputer: "Computer vs Computer",
+	LoadGame:           "Load game",
+	Ultimate:           "Ultimate tic-tac-toe",
+	Misere:             "Misère: Human vs Computer, completing a line loses",
+	ConnectFour:        "Connect Four: Human vs Computer",
+	Wild:               "Wild: Human vs Computer, place X or O on every move",
 }
 
 // String returns the string representation of the GameMode
@@ -34,11 +49,21 @@ func NewModel() choices.Model {
 			HumanVsHuman.String(),
 			HumanVsComputer.String(),
 			ComputerVsComputer.String(),
+			LoadGame.String(),
+			Ultimate.String(),
+			Misere.String(),
+			ConnectFour.String(),
+			Wild.String(),
 		},
 		[]any{
 			HumanVsHuman,
 			HumanVsComputer,
 			ComputerVsComputer,
+			LoadGame,
+			Ultimate,
+			Misere,
+			ConnectFour,
+			Wild,
 		},
 		"Select game Mode:",
 	)
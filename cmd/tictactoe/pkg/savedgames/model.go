@@ -0,0 +1,102 @@
+package savedgames
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"tictactoe/cmd/tictactoe/pkg/choices"
+	"tictactoe/cmd/tictactoe/pkg/computerstrategy"
+	"tictactoe/domain/game"
+	"tictactoe/domain/record"
+)
+
+const (
+	fileExt        = ".json"
+	fileTimeLayout = "2006-01-02_15-04-05"
+)
+
+// Dir returns the directory of saved games.
+func Dir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".tictactoe", "games"), nil
+}
+
+// Save saves the game to a new file in the saved games directory and returns the file path.
+func Save(g *game.Game) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	f, err := createFile(dir, now.Format(fileTimeLayout))
+	if err != nil {
+		return "", err
+	}
+	if err := record.Save(f, record.FromGame(g, now)); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return f.Name(), f.Close()
+}
+
+// createFile creates a new file named after the name in the directory, an existing file is never overwritten:
+// games saved within the same second get the zero-padded numeric suffixes _02, _03 and so on,
+// which keep them in the order of saving by name up to the 99th game of the second.
+func createFile(dir, name string) (*os.File, error) {
+	for i := 1; ; i++ {
+		path := filepath.Join(dir, name+fileExt)
+		if i > 1 {
+			path = filepath.Join(dir, fmt.Sprintf("%s_%02d%s", name, i, fileExt))
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if !errors.Is(err, os.ErrExist) {
+			return f, err
+		}
+	}
+}
+
+// Load loads the game from the file.
+func Load(path string) (*game.Game, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := record.Load(f)
+	if err != nil {
+		return nil, err
+	}
+	return r.Game(computerstrategy.Lookup)
+}
+
+// NewModel creates a new model to choose a saved game, the newest games go first.
+// The selected value is the file path.
+func NewModel(questionTitle string) (choices.Model, error) {
+	dir, err := Dir()
+	if err != nil {
+		return choices.Model{}, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+fileExt))
+	if err != nil {
+		return choices.Model{}, err
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	names := make([]string, len(paths))
+	values := make([]any, len(paths))
+	for i, path := range paths {
+		names[i] = strings.TrimSuffix(filepath.Base(path), fileExt)
+		values[i] = path
+	}
+	return choices.NewModel(names, values, questionTitle), nil
+}
@@ -0,0 +1,30 @@
+package timecontrol
+
+import (
+	"time"
+
+	"tictactoe/cmd/tictactoe/pkg/choices"
+	"tictactoe/domain/clock"
+)
+
+// timeControls are the offered time controls, the zero one means the game is not timed.
+var timeControls = []struct {
+	name string
+	tc   clock.TimeControl
+}{
+	{name: "No clock", tc: clock.TimeControl{}},
+	{name: "Sudden death: 1 minute for the game", tc: clock.SuddenDeath(time.Minute)},
+	{name: "Blitz: 3 minutes + 2 seconds per move", tc: clock.Fischer(3*time.Minute, 2*time.Second)},
+	{name: "Bullet: 10 seconds for every move", tc: clock.PerMove(10 * time.Second)},
+}
+
+// NewModel creates a new time control model, the selected value is a clock.TimeControl.
+func NewModel(questionTitle string) choices.Model {
+	names := make([]string, 0, len(timeControls))
+	values := make([]any, 0, len(timeControls))
+	for _, c := range timeControls {
+		names = append(names, c.name)
+		values = append(values, c.tc)
+	}
+	return choices.NewModel(names, values, questionTitle)
+}
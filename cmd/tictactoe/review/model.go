@@ -0,0 +1,140 @@
+// Package review implements the post-game review model:
+// it steps through every move of a finished game and marks the blunders with the best alternative.
+package review
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tictactoe/domain/analysis"
+	"tictactoe/domain/board"
+	"tictactoe/domain/game"
+	"tictactoe/domain/record"
+	gamereview "tictactoe/domain/review"
+)
+
+// Model is the post-game review model.
+type Model struct {
+	review gamereview.Review
+	// ply is the count of moves played in the shown position.
+	ply       int
+	analyzing bool
+	err       error
+}
+
+// reviewMsg is the computed review.
+type reviewMsg struct {
+	review gamereview.Review
+	err    error
+}
+
+// NewModel creates a new review model of the game,
+// the returned command analyzes the game in the background.
+func NewModel(g *game.Game) (Model, tea.Cmd) {
+	initial, moves := g.InitialBoard(), g.Moves()
+	return Model{analyzing: true}, func() tea.Msg {
+		r, err := gamereview.FromMoves(initial, moves)
+		return reviewMsg{review: r, err: err}
+	}
+}
+
+// Update handles messages from the Bubble Tea runtime.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case reviewMsg:
+		m.analyzing = false
+		m.review, m.err = msg.review, msg.err
+	case tea.KeyMsg:
+		if m.analyzing || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		// show the position before the previous move
+		case "left", "h":
+			if m.ply > 0 {
+				m.ply--
+			}
+		// show the position after the next move
+		case "right", "l":
+			if m.ply < len(m.review.Plies) {
+				m.ply++
+			}
+		// show the initial position
+		case "home":
+			m.ply = 0
+		// show the final position
+		case "end":
+			m.ply = len(m.review.Plies)
+		// show the next blunder
+		case "n":
+			for i := m.ply; i < len(m.review.Plies); i++ {
+				if m.review.Plies[i].IsBlunder() {
+					m.ply = i + 1
+					break
+				}
+			}
+		// show the previous blunder
+		case "p":
+			for i := m.ply - 2; i >= 0; i-- {
+				if m.review.Plies[i].IsBlunder() {
+					m.ply = i + 1
+					break
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// View renders the review model.
+func (m Model) View() string {
+	switch {
+	case m.analyzing:
+		return "Analyzing the game..."
+	case m.err != nil:
+		return fmt.Sprintf("Error: %s", m.err)
+	}
+	result := ""
+	if m.ply == 0 {
+		result += "Review, initial position\n" + m.review.Initial.String() + "\n\n"
+	} else {
+		p := m.review.Plies[m.ply-1]
+		var best *board.Cell
+		if p.IsBlunder() {
+			best = &p.Best.Cell
+		}
+		result += fmt.Sprintf("Review, move %d of %d\n", m.ply, len(m.review.Plies))
+		result += p.After.SprintWithHint(&p.Cell, best) + "\n"
+		result += fmt.Sprintf("%s (%s) played %s", p.Player.Name(), p.CellValue, record.FormatCell(p.Cell))
+		if p.Evaluated {
+			result += ": " + describe(p.Played)
+		}
+		result += "\n"
+		if p.IsBlunder() {
+			result += fmt.Sprintf("Blunder! The position was a %s, the best move was %s: %s\n",
+				p.Best.Outcome, record.FormatCell(p.Best.Cell), describe(p.Best))
+		} else {
+			result += "\n"
+		}
+	}
+	result += fmt.Sprintf("Blunders: %d\n", len(m.review.Blunders()))
+	result += "Press left/right to step through the moves, n/p for the next/previous blunder, esc to return."
+	return result
+}
+
+// describe returns the outcome of the move for the player who has played it, e.g. "win in 2 moves".
+func describe(m analysis.Move) string {
+	switch m.Outcome {
+	case analysis.Win:
+		return fmt.Sprintf("win in %d moves", (m.Plies+1)/2)
+	case analysis.Loss:
+		return fmt.Sprintf("loss in %d moves", m.Plies/2)
+	}
+	return "draw"
+}
+
+// Init initializes the model before the review loop starts.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
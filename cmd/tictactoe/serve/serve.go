@@ -0,0 +1,30 @@
+package serve
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"tictactoe/cmd/tictactoe/pkg/computerstrategy"
+	"tictactoe/domain/httpapi"
+)
+
+// Run runs the serve command: serves the HTTP/JSON API of games until the server fails.
+func Run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(out)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	s := &http.Server{
+		Addr:              *addr,
+		Handler:           httpapi.NewHandler(computerstrategy.Lookup),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintf(out, "Serving the games API on %s\n", *addr)
+	return s.ListenAndServe()
+}
@@ -0,0 +1,87 @@
+package simulate
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"tictactoe/cmd/tictactoe/pkg/computerstrategy"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/simulation"
+)
+
+// Run runs the simulate command: plays many games between two computer strategies
+// and prints the win/draw/loss tables to out.
+func Run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	fs.SetOutput(out)
+	available := "one of " + strings.Join(computer.StrategyIDs(), ", ") + ", optionally with a difficulty level, e.g. minimax/easy"
+	xName := fs.String("x", "wiki", "strategy of the first computer, it plays X in the first game: "+available)
+	oName := fs.String("o", "minimax", "strategy of the second computer, it plays O in the first game: "+available)
+	games := fs.Int("games", 1000, "count of games, colours alternate every game")
+	workers := fs.Int("workers", 0, "count of games played in parallel, 0 means the count of CPUs")
+	seed := fs.Int64("seed", 1, "seed of random choices, the same seed gives the same results")
+	openings := fs.Int("openings", 0, "count of random opening moves played before the strategies take over")
+	size := fs.Int("size", board.DefaultSize, "board size")
+	winLength := fs.Int("win", board.DefaultSize, "count of cells in a row to win")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	first, err := computerstrategy.LookupFactory(*xName)
+	if err != nil {
+		return err
+	}
+	second, err := computerstrategy.LookupFactory(*oName)
+	if err != nil {
+		return err
+	}
+	b, err := board.New(*size, *winLength)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{*xName, *oName} {
+		if err := computer.CheckBoard(name, b); err != nil {
+			return err
+		}
+	}
+
+	start := time.Now()
+	result, err := simulation.Run(simulation.StrategyFactory(first), simulation.StrategyFactory(second), simulation.Config{
+		Games:              *games,
+		Workers:            *workers,
+		Seed:               *seed,
+		RandomOpeningMoves: *openings,
+		Board:              b,
+	})
+	if err != nil {
+		return err
+	}
+	firstName, secondName := first(*seed).String(), second(*seed).String()
+	fmt.Fprintf(out, "%s vs %s: %d games in %s\n\n", firstName, secondName, *games, time.Since(start).Round(time.Millisecond))
+	printTable(out, firstName, secondName, result)
+	return nil
+}
+
+// printTable prints the win/draw/loss table of the result for both strategies.
+func printTable(out io.Writer, firstName, secondName string, r simulation.Result) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "\tGames\tWins\tDraws\tLosses\t")
+	row := func(name string, o simulation.Outcomes) {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t\n", name, o.Games(), o.Wins, o.Draws, o.Losses)
+	}
+	mirror := func(o simulation.Outcomes) simulation.Outcomes {
+		return simulation.Outcomes{Wins: o.Losses, Draws: o.Draws, Losses: o.Wins}
+	}
+	row(firstName+" as X", r.AsX)
+	row(firstName+" as O", r.AsO)
+	row(firstName+" total", r.Total())
+	row(secondName+" as X", mirror(r.AsO))
+	row(secondName+" as O", mirror(r.AsX))
+	row(secondName+" total", mirror(r.Total()))
+	w.Flush()
+}
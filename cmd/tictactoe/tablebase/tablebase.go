@@ -0,0 +1,47 @@
+package tablebase
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer/strategies/tablebase"
+)
+
+// Run runs the tablebase command: solves every position of the variant reachable from the empty 3x3 board
+// and writes the tablebase file that is embedded by the tablebase strategy.
+func Run(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("tablebase", flag.ContinueOnError)
+	fs.SetOutput(out)
+	variantName := fs.String("variant", board.Classic.String(), "board variant: classic or misere")
+	path := fs.String("o", "", "path of the written tablebase file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("output path is required, set it with -o")
+	}
+	v, err := board.ParseVariant(*variantName)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	t, err := tablebase.Generate(v)
+	if err != nil {
+		return err
+	}
+	data, err := t.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*path, data, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s tablebase: %d positions solved in %s, %d bytes written to %s\n",
+		v, t.Len(), time.Since(start).Round(time.Millisecond), len(data), *path)
+	return nil
+}
@@ -0,0 +1,187 @@
+// Package ultimate implements the Ultimate tic-tac-toe game model.
+package ultimate
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"tictactoe/cmd/tictactoe/pkg/choices"
+	"tictactoe/domain/board"
+	"tictactoe/domain/ultimate"
+	"tictactoe/domain/ultimate/mcts"
+)
+
+// computerTimeBudget limits the time of a computer move.
+const computerTimeBudget = time.Second
+
+type viewType int
+
+const (
+	viewTypeOpponentSelection viewType = iota + 1
+	viewTypeGame
+)
+
+// opponent is the choice of players.
+type opponent int
+
+const (
+	humanVsHuman opponent = iota + 1
+	humanVsComputer
+	computerVsHuman
+)
+
+// Model is the Ultimate tic-tac-toe model.
+type Model struct {
+	board         ultimate.Board
+	cursor        ultimate.Move
+	opponentModel choices.Model
+	currentView   viewType
+	// computer plays the computerValue marks, nil means both players are humans.
+	computer      ultimate.Strategy
+	computerValue board.CellValue
+	thinking      bool
+	err           error
+}
+
+// computerMoveMsg is the computed computer move on the board.
+type computerMoveMsg struct {
+	board ultimate.Board
+	move  ultimate.Move
+}
+
+// NewModel creates a new Ultimate tic-tac-toe model.
+func NewModel() Model {
+	return Model{
+		currentView: viewTypeOpponentSelection,
+		opponentModel: choices.NewModel(
+			[]string{"Human vs Human", "Human vs Computer", "Computer vs Human"},
+			[]any{humanVsHuman, humanVsComputer, computerVsHuman},
+			"Choose players, the first one plays X:",
+		),
+	}
+}
+
+// Update handles messages from the Bubble Tea runtime.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.currentView == viewTypeOpponentSelection {
+		child, _ := m.opponentModel.Update(msg)
+		m.opponentModel = child.(choices.Model)
+		o, ok := m.opponentModel.GetSelected().(opponent)
+		if !ok {
+			return m, nil
+		}
+		switch o {
+		case humanVsComputer:
+			m.computer, m.computerValue = mcts.NewStrategy(mcts.WithTimeBudget(computerTimeBudget)), board.OValue
+		case computerVsHuman:
+			m.computer, m.computerValue = mcts.NewStrategy(mcts.WithTimeBudget(computerTimeBudget)), board.XValue
+		}
+		m.currentView = viewTypeGame
+		m.moveCursorToFirstLegalMove()
+		return m, m.playComputerTurn()
+	}
+
+	m.err = nil
+	switch msg := msg.(type) {
+	case computerMoveMsg:
+		if msg.board != m.board {
+			return m, nil
+		}
+		m.thinking = false
+		m.board = m.board.MustPlay(msg.move)
+		m.moveCursorToFirstLegalMove()
+	case tea.KeyMsg:
+		row := m.cursor.Board.RowNumber*ultimate.Size + m.cursor.Cell.RowNumber
+		column := m.cursor.Board.ColumnNumber*ultimate.Size + m.cursor.Cell.ColumnNumber
+		last := ultimate.Size*ultimate.Size - 1
+		switch msg.String() {
+		// move the cursor across the boards of the grid
+		case "up", "k":
+			if row > 0 {
+				row--
+			}
+		case "down", "j":
+			if row < last {
+				row++
+			}
+		case "left", "h":
+			if column > 0 {
+				column--
+			}
+		case "right", "l":
+			if column < last {
+				column++
+			}
+		// play the cell under the cursor
+		case "enter":
+			if m.board.IsCompleted() || m.thinking {
+				return m, nil
+			}
+			b, err := m.board.Play(m.cursor)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.board = b
+			m.moveCursorToFirstLegalMove()
+			return m, m.playComputerTurn()
+		}
+		m.cursor = ultimate.Move{
+			Board: board.Cell{RowNumber: row / ultimate.Size, ColumnNumber: column / ultimate.Size},
+			Cell:  board.Cell{RowNumber: row % ultimate.Size, ColumnNumber: column % ultimate.Size},
+		}
+	}
+	return m, nil
+}
+
+// playComputerTurn returns the command computing the computer move if it is the computer turn.
+func (m *Model) playComputerTurn() tea.Cmd {
+	if m.computer == nil || m.board.IsCompleted() || m.board.CurrentTurnCellValue() != m.computerValue {
+		return nil
+	}
+	m.thinking = true
+	b, s := m.board, m.computer
+	return func() tea.Msg {
+		return computerMoveMsg{board: b, move: s.FindBestMove(b)}
+	}
+}
+
+// moveCursorToFirstLegalMove moves the cursor to the first cell the current player may play.
+func (m *Model) moveCursorToFirstLegalMove() {
+	if moves := m.board.LegalMoves(); len(moves) > 0 {
+		m.cursor = moves[0]
+	}
+}
+
+// View renders the Ultimate tic-tac-toe model.
+func (m Model) View() string {
+	if m.currentView == viewTypeOpponentSelection {
+		return m.opponentModel.View()
+	}
+	if m.board.IsCompleted() {
+		result := m.board.String() + "\n"
+		if w, ok := m.board.Winner(); ok {
+			return result + fmt.Sprintf("Game is over, winner is: %s", w)
+		}
+		return result + "Game is over, Draw"
+	}
+	cursor := m.cursor
+	result := m.board.Sprint(&cursor) + "\n"
+	switch {
+	case m.err != nil:
+		result += fmt.Sprintf("Error: %s", m.err)
+	case m.thinking:
+		result += "Computer is thinking..."
+	default:
+		result += fmt.Sprintf("Current player: %s", m.board.CurrentTurnCellValue())
+	}
+	result += "\nCells of the boards you may play in are shown as :-:, press enter to play the cell under the cursor."
+	return result
+}
+
+// Init initializes the model before the game loop starts.
+func (m Model) Init() tea.Cmd {
+	return nil
+}
@@ -0,0 +1,154 @@
+// Package analysis computes the game-theoretic value of positions:
+// for every empty cell whether it leads to a forced win, draw or loss for the side to move and in how many moves.
+package analysis
+
+import (
+	"errors"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer/strategies/minimax"
+)
+
+// MaxEmptyCells is the maximal count of empty cells of an analyzed position,
+// bigger positions can't be solved in reasonable time.
+const MaxEmptyCells = 16
+
+// ErrTooManyEmptyCells is returned when a position is too big to be solved.
+var ErrTooManyEmptyCells = errors.New("position has too many empty cells to be analyzed, at most 16 empty cells are supported")
+
+// Outcome is the result of the game with perfect play from the point of view of the side to move.
+type Outcome int
+
+const (
+	// Loss means the opponent forces a win.
+	Loss Outcome = iota - 1
+	// Draw means neither side can force a win.
+	Draw
+	// Win means the side to move forces a win.
+	Win
+)
+
+// String returns the string representation of the Outcome.
+func (o Outcome) String() string {
+	switch o {
+	case Win:
+		return "win"
+	case Loss:
+		return "loss"
+	default:
+		return "draw"
+	}
+}
+
+// Move is the analysis of playing an empty cell.
+type Move struct {
+	Cell board.Cell
+	// Mark is the placed mark, it is the mark of the side to move unless the variant is wild.
+	Mark    board.CellValue
+	Outcome Outcome
+	// Plies is the count of moves of both sides until the forced win or loss including this move,
+	// it is zero in a draw.
+	Plies int
+}
+
+// Better returns true if the move is better than the other one for the side to move:
+// wins are better than draws and draws are better than losses,
+// faster wins and slower losses are better.
+func (m Move) Better(other Move) bool {
+	if m.Outcome != other.Outcome {
+		return m.Outcome > other.Outcome
+	}
+	switch m.Outcome {
+	case Win:
+		return m.Plies < other.Plies
+	case Loss:
+		return m.Plies > other.Plies
+	}
+	return false
+}
+
+// Analysis is the analysis of a position.
+type Analysis struct {
+	Board board.Board
+	// Moves contains the analysis of every playable cell in row-major order.
+	Moves []Move
+}
+
+// Analyze analyzes every playable cell of the board for the side to move.
+func Analyze(b board.Board) (Analysis, error) {
+	if b.IsCompleted() {
+		return Analysis{}, board.ErrGameIsOver
+	}
+	if len(b.EmptyCells()) > MaxEmptyCells {
+		return Analysis{}, ErrTooManyEmptyCells
+	}
+	scores := minimax.NewStrategy().ScoreCells(b)
+	a := Analysis{Board: b, Moves: make([]Move, 0, len(scores))}
+	for _, s := range scores {
+		a.Moves = append(a.Moves, moveOf(s))
+	}
+	return a, nil
+}
+
+// moveOf converts the minimax score of the cell to the move analysis.
+func moveOf(s minimax.CellScore) Move {
+	m := Move{Cell: s.Cell, Mark: s.Mark}
+	switch {
+	case s.Score > 0:
+		m.Outcome = Win
+		m.Plies = minimax.WinScore - s.Score
+	case s.Score < 0:
+		m.Outcome = Loss
+		m.Plies = minimax.WinScore + s.Score
+	}
+	return m
+}
+
+// Best returns the best move, the first one in row-major order if several moves are equally good.
+func (a Analysis) Best() Move {
+	best := a.Moves[0]
+	for _, m := range a.Moves[1:] {
+		if m.Better(best) {
+			best = m
+		}
+	}
+	return best
+}
+
+// Outcome returns the outcome of the position for the side to move.
+func (a Analysis) Outcome() Outcome {
+	return a.Best().Outcome
+}
+
+// Move returns the analysis of the cell, it returns false if the cell is not empty.
+// In the wild variant it returns the analysis of placing X, see MarkedMove.
+func (a Analysis) Move(cell board.Cell) (Move, bool) {
+	for _, m := range a.Moves {
+		if m.Cell == cell {
+			return m, true
+		}
+	}
+	return Move{}, false
+}
+
+// MarkedMove returns the analysis of placing the mark on the cell, it returns false if the move is illegal.
+func (a Analysis) MarkedMove(cell board.Cell, mark board.CellValue) (Move, bool) {
+	for _, m := range a.Moves {
+		if m.Cell == cell && m.Mark == mark {
+			return m, true
+		}
+	}
+	return Move{}, false
+}
+
+// BestMoves returns all moves that are as good as the best one.
+func (a Analysis) BestMoves() []Move {
+	best := a.Best()
+	var moves []Move
+	for _, m := range a.Moves {
+		if !best.Better(m) {
+			moves = append(moves, m)
+		}
+	}
+	return moves
+}
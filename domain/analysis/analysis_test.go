@@ -0,0 +1,149 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/modifiedwiki"
+	"tictactoe/domain/computer/strategies/wiki"
+)
+
+func TestAnalyze(t *testing.T) {
+	t.Run("when board is empty should return draw for every cell", func(t *testing.T) {
+		a, err := Analyze(board.Board{})
+		require.NoError(t, err)
+		require.Len(t, a.Moves, 9)
+		for _, m := range a.Moves {
+			require.Equal(t, Draw, m.Outcome)
+			require.Equal(t, 0, m.Plies)
+		}
+		require.Equal(t, Draw, a.Outcome())
+	})
+	t.Run("when player can win should return the win in one move", func(t *testing.T) {
+		a, err := Analyze(board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, board.EmptyValue},
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, Move{Cell: board.MustNewCell(0, 2), Mark: board.XValue, Outcome: Win, Plies: 1}, a.Best())
+		m, ok := a.Move(board.MustNewCell(2, 0))
+		require.True(t, ok)
+		require.Equal(t, Move{Cell: board.MustNewCell(2, 0), Mark: board.XValue, Outcome: Loss, Plies: 2}, m)
+		_, ok = a.Move(board.MustNewCell(0, 0))
+		require.False(t, ok)
+	})
+	t.Run("when opponent has a fork should return the loss", func(t *testing.T) {
+		a, err := Analyze(board.MustNewFromRows([][]board.CellValue{
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.OValue, board.XValue, board.XValue},
+			{board.EmptyValue, board.XValue, board.EmptyValue},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, Loss, a.Outcome())
+		require.Equal(t, Move{Cell: board.MustNewCell(0, 2), Mark: board.XValue, Outcome: Loss, Plies: 2}, a.Best())
+	})
+	t.Run("when the corner is answered on a side should return the forced win", func(t *testing.T) {
+		a, err := Analyze(board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, Win, a.Outcome())
+		require.Equal(t, 5, a.Best().Plies)
+	})
+	t.Run("when game is over should return error", func(t *testing.T) {
+		_, err := Analyze(board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, board.XValue},
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		}))
+		require.ErrorIs(t, err, board.ErrGameIsOver)
+	})
+	t.Run("when position is too big should return error", func(t *testing.T) {
+		_, err := Analyze(board.MustNew(5, 4))
+		require.ErrorIs(t, err, ErrTooManyEmptyCells)
+	})
+}
+
+func TestMove_Better(t *testing.T) {
+	tests := []struct {
+		name  string
+		m     Move
+		other Move
+		want  bool
+	}{
+		{name: "when win is compared with draw should be better", m: Move{Outcome: Win, Plies: 7}, other: Move{Outcome: Draw}, want: true},
+		{name: "when draw is compared with loss should be better", m: Move{Outcome: Draw}, other: Move{Outcome: Loss, Plies: 8}, want: true},
+		{name: "when win is faster should be better", m: Move{Outcome: Win, Plies: 1}, other: Move{Outcome: Win, Plies: 3}, want: true},
+		{name: "when loss is faster should be worse", m: Move{Outcome: Loss, Plies: 2}, other: Move{Outcome: Loss, Plies: 4}, want: false},
+		{name: "when both are draws should not be better", m: Move{Outcome: Draw}, other: Move{Outcome: Draw}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.m.Better(tt.other))
+		})
+	}
+}
+
+func TestAnalysis_BestMoves(t *testing.T) {
+	a, err := Analyze(board.MustNewFromRows([][]board.CellValue{
+		{board.XValue, board.EmptyValue, board.EmptyValue},
+		{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+	}))
+	require.NoError(t, err)
+	// The center is the only reply to the corner opening that doesn't lose.
+	require.Equal(t, []Move{{Cell: board.MustNewCell(1, 1), Mark: board.OValue, Outcome: Draw}}, a.BestMoves())
+}
+
+// TestStrategies_NeverMakeLosingMoves proves the rule-based strategies playing either side from the start
+// never turn a position that is not lost into a loss, whatever the opponent plays.
+// They may miss a forced win and play for a draw.
+func TestStrategies_NeverMakeLosingMoves(t *testing.T) {
+	strategies := []computer.Strategy{wiki.NewStrategy(), modifiedwiki.NewStrategy()}
+	analyses := map[board.Board]Analysis{}
+	analyze := func(b board.Board) Analysis {
+		a, ok := analyses[b]
+		if !ok {
+			var err error
+			a, err = Analyze(b)
+			require.NoError(t, err)
+			analyses[b] = a
+		}
+		return a
+	}
+	for _, s := range strategies {
+		for _, side := range []board.CellValue{board.XValue, board.OValue} {
+			t.Run(fmt.Sprintf("%s as %s", s, side), func(t *testing.T) {
+				seen := map[board.Board]bool{}
+				var walk func(b board.Board)
+				walk = func(b board.Board) {
+					if seen[b] || b.IsCompleted() {
+						return
+					}
+					seen[b] = true
+					if b.CurrentTurnCellValue() != side {
+						for _, cell := range b.EmptyCells() {
+							walk(b.MustSetCellValue(cell))
+						}
+						return
+					}
+					a := analyze(b)
+					m, ok := a.Move(s.FindBestCellForNextTurn(b))
+					require.True(t, ok)
+					if a.Outcome() != Loss {
+						require.NotEqual(t, Loss, m.Outcome, fmt.Sprintf("cell %v\nboard:\n%v", m.Cell, b))
+					}
+					walk(b.MustSetCellValue(m.Cell))
+				}
+				walk(board.Board{})
+			})
+		}
+	}
+}
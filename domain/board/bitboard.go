@@ -0,0 +1,273 @@
+package board
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Mask is a set of cells of a board, one bit per cell in row-major order.
+// It holds the cells of the biggest board.
+type Mask [4]uint64
+
+// Has returns true if the mask contains the cell with the index.
+func (m Mask) Has(i int) bool {
+	return m[i/64]&(1<<(i%64)) != 0
+}
+
+// With returns the mask with the cell with the index added.
+func (m Mask) With(i int) Mask {
+	m[i/64] |= 1 << (i % 64)
+	return m
+}
+
+// Contains returns true if the mask contains every cell of the other mask.
+func (m Mask) Contains(other Mask) bool {
+	return m[0]&other[0] == other[0] && m[1]&other[1] == other[1] &&
+		m[2]&other[2] == other[2] && m[3]&other[3] == other[3]
+}
+
+// Count returns the count of cells in the mask.
+func (m Mask) Count() int {
+	return bits.OnesCount64(m[0]) + bits.OnesCount64(m[1]) + bits.OnesCount64(m[2]) + bits.OnesCount64(m[3])
+}
+
+// Bitboard is a compact representation of the board for fast search: one mask per side
+// and the lines through every cell precomputed for the board dimensions.
+// Moves are not validated, so the caller must play only the playable cells of a not completed board.
+// Bitboard is a value object so is immutable. Bitboards are created by Board.Bitboard,
+// the zero value is not usable.
+type Bitboard struct {
+	geometry *geometry
+	x        Mask
+	o        Mask
+	// count is the count of full cells.
+	count int
+	// winner is the winner, EmptyValue while there is no one.
+	winner CellValue
+	// hash is the Zobrist hash updated on every move.
+	hash Hash
+	// header is the board dimensions and variant without the cells.
+	header header
+}
+
+// header are the fields of the Board other than the cells.
+type header struct {
+	size      int
+	rows      int
+	winLength int
+	variant   Variant
+}
+
+// geometry is shared between bitboards of the same dimensions and the win length.
+type geometry struct {
+	rows    int
+	columns int
+	// cellLines are the lines through the cell with the index.
+	cellLines [][]Mask
+}
+
+// geometryCache caches geometries by the board dimensions and the win length.
+var geometryCache [MaxSize + 1][MaxSize + 1][MaxSize + 1]struct {
+	once     sync.Once
+	geometry *geometry
+}
+
+// geometryOf returns the geometry of the board dimensions and the win length.
+func geometryOf(b Board) *geometry {
+	rows, columns := b.Rows(), b.Columns()
+	c := &geometryCache[rows][columns][b.WinLength()]
+	c.once.Do(func() {
+		g := &geometry{rows: rows, columns: columns, cellLines: make([][]Mask, rows*columns)}
+		for _, line := range b.Lines() {
+			var m Mask
+			for _, cell := range line {
+				m = m.With(cell.RowNumber*columns + cell.ColumnNumber)
+			}
+			for _, cell := range line {
+				i := cell.RowNumber*columns + cell.ColumnNumber
+				g.cellLines[i] = append(g.cellLines[i], m)
+			}
+		}
+		c.geometry = g
+	})
+	return c.geometry
+}
+
+// Bitboard returns the bitboard of the board.
+func (b Board) Bitboard() Bitboard {
+	bb := Bitboard{
+		geometry: geometryOf(b),
+		header:   header{size: b.size, rows: b.rows, winLength: b.winLength, variant: b.variant},
+	}
+	for i := 0; i < bb.Rows(); i++ {
+		for j := 0; j < bb.Columns(); j++ {
+			switch CellValue(b.cells[i][j]) {
+			case XValue:
+				bb.x = bb.x.With(bb.Index(Cell{RowNumber: i, ColumnNumber: j}))
+			case OValue:
+				bb.o = bb.o.With(bb.Index(Cell{RowNumber: i, ColumnNumber: j}))
+			default:
+				continue
+			}
+			bb.count++
+		}
+	}
+	bb.winner, _ = b.Winner()
+	bb.hash = b.Hash()
+	return bb
+}
+
+// Board returns the board of the bitboard.
+func (bb Bitboard) Board() Board {
+	b := Board{size: bb.header.size, rows: bb.header.rows, winLength: bb.header.winLength, variant: bb.header.variant}
+	for i := 0; i < bb.Rows()*bb.Columns(); i++ {
+		cell := bb.Cell(i)
+		b.cells[cell.RowNumber][cell.ColumnNumber] = int8(bb.CellValue(i))
+	}
+	return b
+}
+
+// Rows returns the count of rows.
+func (bb Bitboard) Rows() int {
+	return bb.geometry.rows
+}
+
+// Columns returns the count of columns.
+func (bb Bitboard) Columns() int {
+	return bb.geometry.columns
+}
+
+// Variant returns the variant of the board.
+func (bb Bitboard) Variant() Variant {
+	return bb.header.variant
+}
+
+// Index returns the index of the cell, cells are indexed in row-major order.
+func (bb Bitboard) Index(cell Cell) int {
+	return cell.RowNumber*bb.geometry.columns + cell.ColumnNumber
+}
+
+// Cell returns the cell with the index.
+func (bb Bitboard) Cell(i int) Cell {
+	return Cell{RowNumber: i / bb.geometry.columns, ColumnNumber: i % bb.geometry.columns}
+}
+
+// Masks returns the cells of X and the cells of O.
+func (bb Bitboard) Masks() (x, o Mask) {
+	return bb.x, bb.o
+}
+
+// Hash returns the Zobrist hash of the position, it is equal to the hash of the board.
+func (bb Bitboard) Hash() Hash {
+	return bb.hash
+}
+
+// CellValue returns the value of the cell with the index.
+func (bb Bitboard) CellValue(i int) CellValue {
+	switch {
+	case bb.x.Has(i):
+		return XValue
+	case bb.o.Has(i):
+		return OValue
+	}
+	return EmptyValue
+}
+
+// FullCellsCount returns the number of full cells.
+func (bb Bitboard) FullCellsCount() int {
+	return bb.count
+}
+
+// CurrentTurnCellValue returns the current turn cell value.
+func (bb Bitboard) CurrentTurnCellValue() CellValue {
+	if bb.count%2 == 0 {
+		return XValue
+	}
+	return OValue
+}
+
+// OpponentCellValue returns the cell value of the player who made the last move.
+func (bb Bitboard) OpponentCellValue() CellValue {
+	return -bb.CurrentTurnCellValue()
+}
+
+// Winner returns the winner like Board.Winner does.
+func (bb Bitboard) Winner() (CellValue, bool) {
+	return bb.winner, !bb.winner.IsEmpty()
+}
+
+// IsFull returns true if the board is full.
+func (bb Bitboard) IsFull() bool {
+	return bb.count == bb.geometry.rows*bb.geometry.columns
+}
+
+// IsCompleted returns true if the board is full or there is a winner.
+func (bb Bitboard) IsCompleted() bool {
+	return !bb.winner.IsEmpty() || bb.IsFull()
+}
+
+// AppendPlayable appends the indexes of the playable cells to dst in the order of Board.PlayableCells
+// and returns the extended slice, so the search can reuse the buffer.
+func (bb Bitboard) AppendPlayable(dst []int) []int {
+	full := bb.x
+	for k := range full {
+		full[k] |= bb.o[k]
+	}
+	rows, columns := bb.geometry.rows, bb.geometry.columns
+	if bb.header.variant == Gravity {
+		for j := 0; j < columns; j++ {
+			for i := (rows-1)*columns + j; i >= 0; i -= columns {
+				if !full.Has(i) {
+					dst = append(dst, i)
+					break
+				}
+			}
+		}
+		return dst
+	}
+	n := rows * columns
+	for k := 0; k*64 < n; k++ {
+		empty := ^full[k]
+		if rest := n - k*64; rest < 64 {
+			empty &= 1<<rest - 1
+		}
+		for empty != 0 {
+			dst = append(dst, k*64+bits.TrailingZeros64(empty))
+			empty &= empty - 1
+		}
+	}
+	return dst
+}
+
+// Play returns the bitboard with the current turn cell value placed in the cell with the index.
+func (bb Bitboard) Play(i int) Bitboard {
+	return bb.Place(i, bb.CurrentTurnCellValue())
+}
+
+// Place returns the bitboard with the value placed in the cell with the index regardless of the turn order.
+// Only the lines through the cell are checked for the winner.
+func (bb Bitboard) Place(i int, value CellValue) Bitboard {
+	mover := bb.CurrentTurnCellValue()
+	own := &bb.x
+	if value == OValue {
+		own = &bb.o
+	}
+	*own = own.With(i)
+	bb.count++
+	bb.hash = bb.hash.Play(bb.Cell(i), value)
+	for _, line := range bb.geometry.cellLines[i] {
+		if !own.Contains(line) {
+			continue
+		}
+		switch bb.header.variant {
+		case Misere:
+			bb.winner = -value
+		case Wild:
+			bb.winner = mover
+		default:
+			bb.winner = value
+		}
+		break
+	}
+	return bb
+}
@@ -0,0 +1,109 @@
+package board
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_Bitboard(t *testing.T) {
+	b := MustNewFromRows([][]CellValue{
+		{XValue, OValue, EmptyValue},
+		{EmptyValue, XValue, EmptyValue},
+		{EmptyValue, EmptyValue, EmptyValue},
+	})
+	bb := b.Bitboard()
+	t.Run("should convert the board to the bitboard and back", func(t *testing.T) {
+		require.Equal(t, b, bb.Board())
+		require.Equal(t, 3, bb.FullCellsCount())
+		require.Equal(t, OValue, bb.CurrentTurnCellValue())
+		require.Equal(t, OValue, bb.CellValue(bb.Index(MustNewCell(0, 1))))
+		require.Equal(t, MustNewCell(1, 2), bb.Cell(5))
+	})
+	t.Run("should return the playable cells in row-major order", func(t *testing.T) {
+		require.Equal(t, []int{2, 3, 5, 6, 7, 8}, bb.AppendPlayable(nil))
+	})
+	t.Run("when a line is completed should return the winner", func(t *testing.T) {
+		won := bb.Play(bb.Index(MustNewCell(2, 0))).Play(bb.Index(MustNewCell(2, 2)))
+		w, ok := won.Winner()
+		require.True(t, ok)
+		require.Equal(t, XValue, w)
+		require.True(t, won.IsCompleted())
+	})
+	t.Run("should keep the source bitboard unchanged", func(t *testing.T) {
+		_ = bb.Play(2)
+		require.Equal(t, b, bb.Board())
+	})
+}
+
+func TestBitboard_Play(t *testing.T) {
+	boards := map[string]Board{
+		"classic":    {},
+		"misere":     Board{}.WithVariant(Misere),
+		"wild":       Board{}.WithVariant(Wild),
+		"sized":      MustNew(5, 4),
+		"big":        MustNew(MaxSize, 5),
+		"gravity":    MustNewRectangular(6, 7, 4).WithVariant(Gravity),
+		"misere 4x6": MustNewRectangular(4, 6, 3).WithVariant(Misere),
+	}
+	for name, start := range boards {
+		t.Run("when the board is "+name+" should play like the board", func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(1))
+			for game := 0; game < 20; game++ {
+				b, bb := start, start.Bitboard()
+				for {
+					require.Equal(t, b, bb.Board())
+					w, ok := b.Winner()
+					gotW, gotOk := bb.Winner()
+					require.Equal(t, w, gotW)
+					require.Equal(t, ok, gotOk)
+					require.Equal(t, b.IsCompleted(), bb.IsCompleted())
+					if b.IsCompleted() {
+						break
+					}
+					cells := b.PlayableCells()
+					indexes := bb.AppendPlayable(nil)
+					require.Len(t, indexes, len(cells))
+					for k, i := range indexes {
+						require.Equal(t, cells[k], bb.Cell(i))
+					}
+					k := rnd.Intn(len(cells))
+					if start.Variant() == Wild && rnd.Intn(2) == 0 {
+						b, bb = mustPlaceCellValue(b, cells[k], OValue), bb.Place(indexes[k], OValue)
+						continue
+					}
+					b, bb = b.MustSetCellValue(cells[k]), bb.Play(indexes[k])
+				}
+			}
+		})
+	}
+}
+
+// mustPlaceCellValue is like Board.PlaceCellValue but panics on error.
+func mustPlaceCellValue(b Board, cell Cell, value CellValue) Board {
+	r, err := b.PlaceCellValue(cell, value)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func BenchmarkBoard_Winner(b *testing.B) {
+	board := MustNewFromRows([][]CellValue{
+		{XValue, OValue, XValue},
+		{XValue, OValue, EmptyValue},
+		{OValue, XValue, EmptyValue},
+	})
+	bb := board.Bitboard()
+	b.Run("board", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			board.MustSetCellValue(MustNewCell(2, 2)).IsCompleted()
+		}
+	})
+	b.Run("bitboard", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bb.Play(8).IsCompleted()
+		}
+	})
+}
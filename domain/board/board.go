@@ -3,6 +3,7 @@ package board
 import (
 	"errors"
 	"strings"
+	"sync"
 )
 
 var (
@@ -10,34 +11,226 @@ var (
 	ErrGameIsOver = errors.New("game is over")
 	// ErrCellIsNotEmpty is returned when the cell is not empty.
 	ErrCellIsNotEmpty = errors.New("cell is not empty")
+	// ErrInvalidSize is returned when a board size is out of range.
+	ErrInvalidSize = errors.New("invalid board size, size must be between 3 and 15, inclusive")
+	// ErrInvalidWinLength is returned when a win length is out of range.
+	ErrInvalidWinLength = errors.New("invalid win length, win length must be between 3 and the board size, inclusive")
+	// ErrCellIsNotPlayable is returned when a mark is placed above an empty cell in the gravity variant.
+	ErrCellIsNotPlayable = errors.New("cell is not playable, marks fall to the lowest empty cell of the column")
+	// ErrColumnIsFull is returned when a mark is dropped into a full column.
+	ErrColumnIsFull = errors.New("column is full")
+	// ErrInvalidCellValue is returned when a placed cell value is not X or O.
+	ErrInvalidCellValue = errors.New("invalid cell value, it must be X or O")
+	// ErrInvalidRows is returned when rows can't be converted to a board.
+	ErrInvalidRows = errors.New("invalid rows, rows must form a square matrix of valid cell values")
 )
 
 const (
-	boardSize = 3
+	// DefaultSize is the size of the classic board.
+	DefaultSize = 3
+	// MinSize is the minimal supported board size.
+	MinSize = 3
+	// MaxSize is the maximal supported board size.
+	MaxSize = 15
+	// MinWinLength is the minimal supported count of cells in a row to win.
+	MinWinLength = 3
 )
 
-// Board is a 3x3 matrix of CellValue.
+// Board is a Rows x Columns matrix of CellValue, classic boards are square.
 // Board is the game board.
 // Board is a value object so is immutable.
 // Board is responsible for managing the board state and calculating the winner: X or 0 or the end of the game.
-type Board [boardSize][boardSize]CellValue
+// The player who first places WinLength marks in a horizontal, vertical or diagonal row wins,
+// in the Misere variant the player loses instead.
+// The zero value is an empty classic 3x3 board.
+type Board struct {
+	// size is the count of columns, 0 means DefaultSize.
+	size int
+	// rows is the count of rows, 0 means the board is square.
+	rows int
+	// winLength is the count of cells in a row to win, 0 means the board size.
+	winLength int
+	// variant decides the winner, the zero value is Classic.
+	variant Variant
+	// cells are stored as int8 to keep the board cheap to copy.
+	cells [MaxSize][MaxSize]int8
+}
+
+// New creates an empty square board with the given size and win length.
+func New(size, winLength int) (Board, error) {
+	return NewRectangular(size, size, winLength)
+}
+
+// NewRectangular creates an empty board with the given count of rows and columns and the win length.
+// The win length must fit into both dimensions.
+func NewRectangular(rows, columns, winLength int) (Board, error) {
+	if rows < MinSize || rows > MaxSize || columns < MinSize || columns > MaxSize {
+		return Board{}, ErrInvalidSize
+	}
+	if winLength < MinWinLength || winLength > min(rows, columns) {
+		return Board{}, ErrInvalidWinLength
+	}
+	// Keep the default dimensions as zero values, so equal positions are equal boards.
+	b := Board{}
+	if columns != DefaultSize {
+		b.size = columns
+	}
+	if rows != columns {
+		b.rows = rows
+	}
+	if winLength != columns {
+		b.winLength = winLength
+	}
+	return b, nil
+}
+
+// MustNewRectangular is like NewRectangular but panics if the dimensions or the win length are invalid.
+func MustNewRectangular(rows, columns, winLength int) Board {
+	b, err := NewRectangular(rows, columns, winLength)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// MustNew is like New but panics if the size or the win length is invalid.
+func MustNew(size, winLength int) Board {
+	b, err := New(size, winLength)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// NewFromRows creates a board from the given rows.
+// The board size is the count of rows and the win length is equal to the board size.
+func NewFromRows(rows [][]CellValue) (Board, error) {
+	b, err := New(len(rows), len(rows))
+	if err != nil {
+		return Board{}, err
+	}
+	for i, row := range rows {
+		if len(row) != len(rows) {
+			return Board{}, ErrInvalidRows
+		}
+		for j, v := range row {
+			if v != EmptyValue && v != XValue && v != OValue {
+				return Board{}, ErrInvalidRows
+			}
+			b.cells[i][j] = int8(v)
+		}
+	}
+	return b, nil
+}
+
+// MustNewFromRows is like NewFromRows but panics if the rows are invalid.
+func MustNewFromRows(rows [][]CellValue) Board {
+	b, err := NewFromRows(rows)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Size returns the board dimension, it is the count of columns of a rectangular board.
+func (b Board) Size() int {
+	if b.size == 0 {
+		return DefaultSize
+	}
+	return b.size
+}
+
+// Rows returns the count of rows.
+func (b Board) Rows() int {
+	if b.rows == 0 {
+		return b.Size()
+	}
+	return b.rows
+}
+
+// Columns returns the count of columns.
+func (b Board) Columns() int {
+	return b.Size()
+}
+
+// WinLength returns the count of cells in a row needed to win.
+func (b Board) WinLength() int {
+	if b.winLength == 0 {
+		return b.Size()
+	}
+	return b.winLength
+}
+
+// WithVariant returns the board played by the rules of the variant.
+func (b Board) WithVariant(v Variant) Board {
+	b.variant = v
+	return b
+}
+
+// Variant returns the variant of the board.
+func (b Board) Variant() Variant {
+	return b.variant
+}
+
+// Contains returns true if the cell is inside the board.
+func (b Board) Contains(cell Cell) bool {
+	return cell.RowNumber >= 0 && cell.ColumnNumber >= 0 &&
+		cell.RowNumber < b.Rows() && cell.ColumnNumber < b.Columns()
+}
+
+// NewCell creates a Cell that is inside the board.
+func (b Board) NewCell(row, coll int) (Cell, error) {
+	c := Cell{RowNumber: row, ColumnNumber: coll}
+	if !b.Contains(c) {
+		return Cell{}, ErrInvalidCell
+	}
+	return c, nil
+}
 
 // IsEmptyCell returns true if the cell is empty.
 func (b Board) IsEmptyCell(cell Cell) bool {
-	return b[cell.RowNumber][cell.ColumnNumber].IsEmpty()
+	return b.Contains(cell) && b.CellValue(cell).IsEmpty()
 }
 
 // SetCellValue returns a new board with the set current turn cell value.
 func (b Board) SetCellValue(cell Cell) (Board, error) {
+	if !b.Contains(cell) {
+		return Board{}, ErrInvalidCell
+	}
 	if !b.IsEmptyCell(cell) {
 		return Board{}, ErrCellIsNotEmpty
 	}
 	if b.IsCompleted() {
 		return Board{}, ErrGameIsOver
 	}
-	var r Board
-	copy(r[:], b[:])
-	r[cell.RowNumber][cell.ColumnNumber] = b.CurrentTurnCellValue()
+	if !b.isPlayable(cell) {
+		return Board{}, ErrCellIsNotPlayable
+	}
+	r := b
+	r.cells[cell.RowNumber][cell.ColumnNumber] = int8(b.CurrentTurnCellValue())
+	return r, nil
+}
+
+// PlaceCellValue returns a new board with the value set to the cell regardless of the turn order.
+// It is used by variants where the placed mark doesn't follow from the count of full cells.
+func (b Board) PlaceCellValue(cell Cell, value CellValue) (Board, error) {
+	if value != XValue && value != OValue {
+		return Board{}, ErrInvalidCellValue
+	}
+	if !b.Contains(cell) {
+		return Board{}, ErrInvalidCell
+	}
+	if !b.IsEmptyCell(cell) {
+		return Board{}, ErrCellIsNotEmpty
+	}
+	if b.IsCompleted() {
+		return Board{}, ErrGameIsOver
+	}
+	if !b.isPlayable(cell) {
+		return Board{}, ErrCellIsNotPlayable
+	}
+	r := b
+	r.cells[cell.RowNumber][cell.ColumnNumber] = int8(value)
 	return r, nil
 }
 
@@ -48,17 +241,30 @@ func (b Board) String() string {
 
 // Sprint returns the string representation of the board.
 func (b Board) Sprint(cursor *Cell) string {
+	return b.SprintWithHint(cursor, nil)
+}
+
+// SprintWithHint returns the string representation of the board with the cursor and the hinted cell.
+// The cursor is shown as [-], the hint as (-) and both on the same cell as {-}.
+func (b Board) SprintWithHint(cursor, hint *Cell) string {
+	rows, columns := b.Rows(), b.Columns()
 	result := ""
-	for i := 0; i < len(b); i++ {
-		str := make([]string, len(b))
-		for j := 0; j < len(b); j++ {
-			v := b[i][j].String()
-			if cursor != nil && i == cursor.RowNumber && j == cursor.ColumnNumber {
+	for i := 0; i < rows; i++ {
+		str := make([]string, columns)
+		for j := 0; j < columns; j++ {
+			v := CellValue(b.cells[i][j]).String()
+			isCursor := cursor != nil && i == cursor.RowNumber && j == cursor.ColumnNumber
+			isHint := hint != nil && i == hint.RowNumber && j == hint.ColumnNumber
+			switch {
+			case isCursor && isHint:
+				str[j] = "{" + v + "}"
+			case isCursor:
 				str[j] = "[" + v + "]"
-				continue
+			case isHint:
+				str[j] = "(" + v + ")"
+			default:
+				str[j] = " " + v + " "
 			}
-
-			str[j] = " " + v + " "
 		}
 		result += strings.Join(str, " | ") + "\n"
 	}
@@ -98,10 +304,11 @@ func (b Board) IsCompleted() bool {
 
 // FullCellsCount returns the number of full cells.
 func (b Board) FullCellsCount() int {
+	rows, columns := b.Rows(), b.Columns()
 	c := 0
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if !b[i][j].IsEmpty() {
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			if !CellValue(b.cells[i][j]).IsEmpty() {
 				c++
 			}
 		}
@@ -111,58 +318,58 @@ func (b Board) FullCellsCount() int {
 
 // Winner returns the Winner CellValue if there is one, otherwise EmptyValue.
 // It also returns true if there is a winner, otherwise false.
-// The method support only 3x3 board.
+// In the Misere variant the winner is the opponent of the player who has completed a line.
 func (b Board) Winner() (CellValue, bool) {
-	for i := 0; i < boardSize; i++ {
-		// cols
-		if b[i][0] == b[i][1] && b[i][1] == b[i][2] && !b[i][0].IsEmpty() {
-			return b[i][0], true
+	for _, line := range b.Lines() {
+		v := b.CellValue(line[0])
+		if v.IsEmpty() {
+			continue
 		}
-		// rows
-		if b[0][i] == b[1][i] && b[1][i] == b[2][i] && !b[0][i].IsEmpty() {
-			return b[0][i], true
+		won := true
+		for _, cell := range line[1:] {
+			if b.CellValue(cell) != v {
+				won = false
+				break
+			}
+		}
+		if won {
+			switch b.variant {
+			case Misere:
+				return -v, true
+			case Wild:
+				// The line is completed by the last move whatever its mark is.
+				return b.OpponentCellValue(), true
+			}
+			return v, true
 		}
-	}
-	// diagonals
-	if b[0][0] == b[1][1] && b[1][1] == b[2][2] && !b[0][0].IsEmpty() {
-		return b[0][0], true
-	}
-	if b[0][2] == b[1][1] && b[1][1] == b[2][0] && !b[0][2].IsEmpty() {
-		return b[0][2], true
 	}
 	return EmptyValue, false
 }
 
 // IsFull returns true if the board is full.
 func (b Board) IsFull() bool {
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if b[i][j].IsEmpty() {
-				return false
-			}
-		}
-	}
-	return true
+	return b.FullCellsCount() == b.Rows()*b.Columns()
 }
 
 // CellValue returns the cell value.
 func (b Board) CellValue(cell Cell) CellValue {
-	return b[cell.RowNumber][cell.ColumnNumber]
+	return CellValue(b.cells[cell.RowNumber][cell.ColumnNumber])
 }
 
 // MidCell returns the middle cell.
-// It supports only 3x3 board.
+// For even sizes it returns the bottom right cell of the four central cells.
 func (b Board) MidCell() Cell {
-	return Cell{RowNumber: 1, ColumnNumber: 1}
+	return Cell{RowNumber: b.Rows() / 2, ColumnNumber: b.Columns() / 2}
 }
 
 // Corners returns the corners of the board.
 func (b Board) Corners() []Cell {
+	lastRow, lastColumn := b.Rows()-1, b.Columns()-1
 	return []Cell{
 		{RowNumber: 0, ColumnNumber: 0},
-		{RowNumber: 0, ColumnNumber: boardSize - 1},
-		{RowNumber: boardSize - 1, ColumnNumber: 0},
-		{RowNumber: boardSize - 1, ColumnNumber: boardSize - 1},
+		{RowNumber: 0, ColumnNumber: lastColumn},
+		{RowNumber: lastRow, ColumnNumber: 0},
+		{RowNumber: lastRow, ColumnNumber: lastColumn},
 	}
 }
 
@@ -175,25 +382,133 @@ func (b Board) OpponentCellValue() CellValue {
 	return XValue
 }
 
-// SideCells returns the side cells of the board.
-// It supports only 3x3 board.
+// SideCells returns the side cells of the board: the border cells that are not corners.
 func (b Board) SideCells() []Cell {
-	return []Cell{
-		{RowNumber: 0, ColumnNumber: 1},
-		{RowNumber: 1, ColumnNumber: 0},
-		{RowNumber: 1, ColumnNumber: 2},
-		{RowNumber: 2, ColumnNumber: 1},
+	rows, columns := b.Rows(), b.Columns()
+	var cells []Cell
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			onRowBorder := i == 0 || i == rows-1
+			onColBorder := j == 0 || j == columns-1
+			if onRowBorder != onColBorder {
+				cells = append(cells, Cell{RowNumber: i, ColumnNumber: j})
+			}
+		}
 	}
+	return cells
 }
 
 // FindFirstEmptyCell returns the first empty cell.
 func (b Board) FindFirstEmptyCell() *Cell {
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if b[i][j].IsEmpty() {
+	rows, columns := b.Rows(), b.Columns()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			if CellValue(b.cells[i][j]).IsEmpty() {
 				return &Cell{RowNumber: i, ColumnNumber: j}
 			}
 		}
 	}
 	return nil
 }
+
+// EmptyCells returns all empty cells in row-major order.
+func (b Board) EmptyCells() []Cell {
+	rows, columns := b.Rows(), b.Columns()
+	cells := make([]Cell, 0, rows*columns)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			if CellValue(b.cells[i][j]).IsEmpty() {
+				cells = append(cells, Cell{RowNumber: i, ColumnNumber: j})
+			}
+		}
+	}
+	return cells
+}
+
+// PlayableCells returns the cells a mark can be placed in:
+// the lowest empty cell of every column from left to right in the gravity variant,
+// all empty cells in row-major order otherwise.
+func (b Board) PlayableCells() []Cell {
+	if b.variant != Gravity {
+		return b.EmptyCells()
+	}
+	cells := make([]Cell, 0, b.Columns())
+	for j := 0; j < b.Columns(); j++ {
+		if cell, err := b.DropCell(j); err == nil {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+// DropCell returns the lowest empty cell of the column, the cell a dropped mark falls to.
+func (b Board) DropCell(column int) (Cell, error) {
+	if column < 0 || column >= b.Columns() {
+		return Cell{}, ErrInvalidCell
+	}
+	for i := b.Rows() - 1; i >= 0; i-- {
+		if CellValue(b.cells[i][column]).IsEmpty() {
+			return Cell{RowNumber: i, ColumnNumber: column}, nil
+		}
+	}
+	return Cell{}, ErrColumnIsFull
+}
+
+// isPlayable returns true if a mark can be placed in the empty cell:
+// in the gravity variant the cell below must be full.
+func (b Board) isPlayable(cell Cell) bool {
+	if b.variant != Gravity || cell.RowNumber == b.Rows()-1 {
+		return true
+	}
+	return !CellValue(b.cells[cell.RowNumber+1][cell.ColumnNumber]).IsEmpty()
+}
+
+// Lines returns every line of WinLength cells that wins the game when it is filled by one player.
+// The lines are ordered as follows: for each index the row lines, then the column lines,
+// then the diagonal lines and finally the anti-diagonal lines.
+// The result is shared between boards of the same dimensions and must not be modified.
+func (b Board) Lines() [][]Cell {
+	rows, columns, winLength := b.Rows(), b.Columns(), b.WinLength()
+	c := &linesCache[rows][columns][winLength]
+	c.once.Do(func() {
+		c.lines = buildLines(rows, columns, winLength)
+	})
+	return c.lines
+}
+
+// linesCache caches lines by the board dimensions and the win length, lines are requested on every Winner call.
+var linesCache [MaxSize + 1][MaxSize + 1][MaxSize + 1]struct {
+	once  sync.Once
+	lines [][]Cell
+}
+
+// buildLines builds all lines of winLength cells for the rows x columns board.
+func buildLines(rows, columns, winLength int) [][]Cell {
+	var lines [][]Cell
+	line := func(row, col, dRow, dCol int) []Cell {
+		cells := make([]Cell, winLength)
+		for k := 0; k < winLength; k++ {
+			cells[k] = Cell{RowNumber: row + k*dRow, ColumnNumber: col + k*dCol}
+		}
+		return cells
+	}
+	for i := 0; i < max(rows, columns); i++ {
+		for start := 0; i < rows && start+winLength <= columns; start++ {
+			lines = append(lines, line(i, start, 0, 1))
+		}
+		for start := 0; i < columns && start+winLength <= rows; start++ {
+			lines = append(lines, line(start, i, 1, 0))
+		}
+	}
+	for i := 0; i+winLength <= rows; i++ {
+		for j := 0; j+winLength <= columns; j++ {
+			lines = append(lines, line(i, j, 1, 1))
+		}
+	}
+	for i := 0; i+winLength <= rows; i++ {
+		for j := winLength - 1; j < columns; j++ {
+			lines = append(lines, line(i, j, 1, -1))
+		}
+	}
+	return lines
+}
@@ -17,21 +17,21 @@ func Test_board_isEmptyCell(t *testing.T) {
 	}{
 		{
 			name: "when cell is empty should return true",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, XValue, EmptyValue},
-			},
+			}),
 			args: args{cell: Cell{RowNumber: 0, ColumnNumber: 2}},
 			want: true,
 		},
 		{
 			name: "when cell is not empty should return false",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, XValue, EmptyValue},
-			},
+			}),
 			args: args{cell: Cell{RowNumber: 2, ColumnNumber: 1}},
 			want: false,
 		},
@@ -55,54 +55,54 @@ func TestBoard_SetCellValue(t *testing.T) {
 		{
 			name: "when cell is not empty should return error",
 			cell: MustNewCell(0, 0),
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 			wantErr: ErrCellIsNotEmpty,
 			want:    Board{},
 		},
 		{
 			name: "when games is over should return error",
 			cell: MustNewCell(0, 2),
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, EmptyValue},
 				{OValue, XValue, OValue},
 				{OValue, EmptyValue, XValue},
-			},
+			}),
 			wantErr: ErrGameIsOver,
 			want:    Board{},
 		},
 		{
 			name: "when 0 turn should update Board and set 0 value for the cell",
 			cell: MustNewCell(0, 0),
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, OValue, XValue},
 				{EmptyValue, XValue, EmptyValue},
 				{OValue, XValue, OValue},
-			},
+			}),
 			wantErr: nil,
-			want: Board{
+			want: MustNewFromRows([][]CellValue{
 				{XValue, OValue, XValue},
 				{EmptyValue, XValue, EmptyValue},
 				{OValue, XValue, OValue},
-			},
+			}),
 		},
 		{
 			name: "when X turn should update Board and set X value for the cell",
 			cell: MustNewCell(0, 0),
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, OValue, EmptyValue},
 				{XValue, XValue, OValue},
 				{OValue, XValue, EmptyValue},
-			},
+			}),
 			wantErr: nil,
-			want: Board{
+			want: MustNewFromRows([][]CellValue{
 				{XValue, OValue, EmptyValue},
 				{XValue, XValue, OValue},
 				{OValue, XValue, EmptyValue},
-			},
+			}),
 		},
 	}
 	for _, tt := range tests {
@@ -123,73 +123,73 @@ func TestBoard_Winner(t *testing.T) {
 	}{
 		{
 			name: "when Board has only 2 'X' or 2 'O' in row  should return empty CellValue and false",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{OValue, OValue, EmptyValue},
 				{XValue, XValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 		},
 		{
 			name: "when Board has only 2 '0' in column or 2 'X' in row should return empty CellValue and false",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, OValue, EmptyValue},
 				{XValue, EmptyValue, XValue},
 				{EmptyValue, OValue, EmptyValue},
-			},
+			}),
 		},
 		{
 			name: "when Board has only 2 'X' in diagonal should return empty CellValue and false",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, OValue, OValue},
 				{EmptyValue, XValue, EmptyValue},
 				{OValue, OValue, EmptyValue},
-			},
+			}),
 		},
 		{
 			name: "when Board has only 2 '0' in diagonal  should return empty CellValue and false",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, EmptyValue, XValue},
 				{EmptyValue, OValue, EmptyValue},
 				{XValue, EmptyValue, OValue},
-			},
+			}),
 		},
 		{
 			name: "when Board has 3 'X' in row  should return 'X' CellValue and true",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, OValue, EmptyValue},
 				{XValue, XValue, XValue},
 				{EmptyValue, OValue, EmptyValue},
-			},
+			}),
 			wantCellValue: XValue,
 			wantExist:     true,
 		},
 		{
 			name: "when Board has 3 '0' in column  should return '0' CellValue and true",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, OValue, EmptyValue},
 				{XValue, OValue, XValue},
 				{EmptyValue, OValue, EmptyValue},
-			},
+			}),
 			wantCellValue: OValue,
 			wantExist:     true,
 		},
 		{
 			name: "when Board has 3 'X' in diagonal should return 'X' CellValue and true",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, OValue, OValue},
 				{EmptyValue, XValue, EmptyValue},
 				{OValue, OValue, XValue},
-			},
+			}),
 			wantCellValue: XValue,
 			wantExist:     true,
 		},
 		{
 			name: "when Board has 3 '0' in diagonal  should return '0' CellValue and true",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, OValue},
 				{EmptyValue, OValue, XValue},
 				{OValue, EmptyValue, XValue},
-			},
+			}),
 			wantCellValue: OValue,
 			wantExist:     true,
 		},
@@ -211,29 +211,29 @@ func TestBoard_FullCellsCount(t *testing.T) {
 	}{
 		{
 			name: "when Board is empty should return 0",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 			want: 0,
 		},
 		{
 			name: "when Board is full should return 9",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, OValue, OValue},
 				{XValue, OValue, XValue},
 				{OValue, XValue, XValue},
-			},
+			}),
 			want: 9,
 		},
 		{
 			name: "when Board is almost full should return 8",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, OValue, OValue},
 				{XValue, OValue, XValue},
 				{EmptyValue, XValue, XValue},
-			},
+			}),
 			want: 8,
 		},
 	}
@@ -253,29 +253,29 @@ func TestBoard_IsFull(t *testing.T) {
 	}{
 		{
 			name: "when Board is full should return true",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, OValue, OValue},
 				{XValue, OValue, XValue},
 				{OValue, XValue, XValue},
-			},
+			}),
 			want: true,
 		},
 		{
 			name: "when Board is empty should return false",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 			want: false,
 		},
 		{
 			name: "when Board is almost full should return false",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, OValue, OValue},
 				{XValue, OValue, XValue},
 				{EmptyValue, XValue, XValue},
-			},
+			}),
 			want: false,
 		},
 	}
@@ -290,20 +290,20 @@ func TestBoard_IsFull(t *testing.T) {
 func TestBoard_MustSetCellValue1(t *testing.T) {
 	t.Run("when SetCellValue return an error should panic", func(t *testing.T) {
 		require.Panics(t, func() {
-			b := Board{
+			b := MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			}
+			})
 			b.MustSetCellValue(MustNewCell(0, 0))
 		})
 	})
 	t.Run("when cell is correct should set new value", func(t *testing.T) {
-		b := Board{
+		b := MustNewFromRows([][]CellValue{
 			{XValue, EmptyValue, EmptyValue},
 			{EmptyValue, EmptyValue, EmptyValue},
 			{EmptyValue, EmptyValue, EmptyValue},
-		}
+		})
 		b.MustSetCellValue(MustNewCell(0, 1))
 	})
 }
@@ -316,29 +316,29 @@ func TestBoard_CurrentTurnCellValue(t *testing.T) {
 	}{
 		{
 			name: "when Board is empty should return X",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 			want: XValue,
 		},
 		{
 			name: "when Board has odd played cells should return 0",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, EmptyValue},
 				{EmptyValue, OValue, EmptyValue},
 				{EmptyValue, EmptyValue, XValue},
-			},
+			}),
 			want: OValue,
 		},
 		{
 			name: "when Board has even played cells should return X",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, OValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{OValue, EmptyValue, XValue},
-			},
+			}),
 			want: XValue,
 		},
 	}
@@ -358,29 +358,29 @@ func TestBoard_IsCompleted(t *testing.T) {
 	}{
 		{
 			name: "when Board is not full and there are no winner should return false",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, OValue},
 				{EmptyValue, XValue, OValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 			want: false,
 		},
 		{
 			name: "when Board has winner should return true",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, OValue},
 				{EmptyValue, XValue, OValue},
 				{EmptyValue, EmptyValue, XValue},
-			},
+			}),
 			want: true,
 		},
 		{
 			name: "when Board is full should return true",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, OValue, XValue},
 				{XValue, OValue, XValue},
 				{OValue, XValue, OValue},
-			},
+			}),
 			want: true,
 		},
 	}
@@ -394,11 +394,11 @@ func TestBoard_IsCompleted(t *testing.T) {
 
 func TestBoard_MidCell(t *testing.T) {
 	t.Run("should return middle cell", func(t *testing.T) {
-		b := Board{
+		b := MustNewFromRows([][]CellValue{
 			{XValue, EmptyValue, OValue},
 			{XValue, OValue, XValue},
 			{OValue, EmptyValue, XValue},
-		}
+		})
 		got := b.MidCell()
 		want := MustNewCell(1, 1)
 		require.Equal(t, want, got)
@@ -407,11 +407,11 @@ func TestBoard_MidCell(t *testing.T) {
 
 func TestBoard_Corners(t *testing.T) {
 	t.Run("should return corners", func(t *testing.T) {
-		b := Board{
+		b := MustNewFromRows([][]CellValue{
 			{XValue, EmptyValue, OValue},
 			{XValue, OValue, XValue},
 			{OValue, EmptyValue, XValue},
-		}
+		})
 		got := b.Corners()
 		want := []Cell{
 			MustNewCell(0, 0),
@@ -425,11 +425,11 @@ func TestBoard_Corners(t *testing.T) {
 
 func TestBoard_SideCells(t *testing.T) {
 	t.Run("should return side cells", func(t *testing.T) {
-		b := Board{
+		b := MustNewFromRows([][]CellValue{
 			{XValue, EmptyValue, OValue},
 			{XValue, OValue, XValue},
 			{OValue, EmptyValue, XValue},
-		}
+		})
 		got := b.SideCells()
 		want := []Cell{
 			MustNewCell(0, 1),
@@ -443,11 +443,11 @@ func TestBoard_SideCells(t *testing.T) {
 
 func TestBoard_CellValue(t *testing.T) {
 	t.Run("should return cell value", func(t *testing.T) {
-		b := Board{
+		b := MustNewFromRows([][]CellValue{
 			{XValue, EmptyValue, OValue},
 			{XValue, OValue, XValue},
 			{OValue, EmptyValue, XValue},
-		}
+		})
 		require.Equal(t, OValue, b.CellValue(MustNewCell(0, 2)))
 		require.Equal(t, XValue, b.CellValue(MustNewCell(1, 2)))
 	})
@@ -461,29 +461,29 @@ func TestBoard_OpponentCellValue(t *testing.T) {
 	}{
 		{
 			name: "when Board is empty should return O",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 			want: OValue,
 		},
 		{
 			name: "when Board has odd played cells should return X",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, EmptyValue},
 				{EmptyValue, OValue, EmptyValue},
 				{EmptyValue, EmptyValue, XValue},
-			},
+			}),
 			want: XValue,
 		},
 		{
 			name: "when Board has even played cells should return O",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, OValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{OValue, EmptyValue, XValue},
-			},
+			}),
 			want: OValue,
 		},
 	}
@@ -503,29 +503,29 @@ func TestBoard_FindFirstEmptyCell(t *testing.T) {
 	}{
 		{
 			name: "when Board is empty should return first cell",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 			want: &Cell{RowNumber: 0, ColumnNumber: 0},
 		},
 		{
 			name: "when Board's  second cell is empty and first cell is not empty should return the second cell",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
 				{EmptyValue, EmptyValue, EmptyValue},
-			},
+			}),
 			want: &Cell{RowNumber: 0, ColumnNumber: 1},
 		},
 		{
 			name: "when Board is full should return nil",
-			b: Board{
+			b: MustNewFromRows([][]CellValue{
 				{XValue, OValue, XValue},
 				{XValue, OValue, XValue},
 				{OValue, XValue, OValue},
-			},
+			}),
 			want: nil,
 		},
 	}
@@ -536,3 +536,463 @@ func TestBoard_FindFirstEmptyCell(t *testing.T) {
 		})
 	}
 }
+
+// withWinLength returns a copy of the board with the given win length.
+func withWinLength(b Board, winLength int) Board {
+	b.winLength = winLength
+	return b
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		size          int
+		winLength     int
+		wantSize      int
+		wantWinLength int
+		wantErr       error
+	}{
+		{
+			name:      "when size is too small should return error",
+			size:      MinSize - 1,
+			winLength: MinWinLength,
+			wantErr:   ErrInvalidSize,
+		},
+		{
+			name:      "when size is too big should return error",
+			size:      MaxSize + 1,
+			winLength: MinWinLength,
+			wantErr:   ErrInvalidSize,
+		},
+		{
+			name:      "when win length is too small should return error",
+			size:      5,
+			winLength: MinWinLength - 1,
+			wantErr:   ErrInvalidWinLength,
+		},
+		{
+			name:      "when win length is bigger than size should return error",
+			size:      5,
+			winLength: 6,
+			wantErr:   ErrInvalidWinLength,
+		},
+		{
+			name:          "when size and win length are valid should return empty board",
+			size:          15,
+			winLength:     5,
+			wantSize:      15,
+			wantWinLength: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.size, tt.winLength)
+			require.Equal(t, tt.wantErr, err)
+			if err != nil {
+				return
+			}
+			require.Equal(t, tt.wantSize, got.Size())
+			require.Equal(t, tt.wantWinLength, got.WinLength())
+			require.Equal(t, 0, got.FullCellsCount())
+		})
+	}
+	t.Run("when size and win length are classic should return zero board", func(t *testing.T) {
+		require.Equal(t, Board{}, MustNew(DefaultSize, DefaultSize))
+	})
+}
+
+func TestNewFromRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    [][]CellValue
+		wantErr error
+	}{
+		{
+			name:    "when rows are too few should return error",
+			rows:    [][]CellValue{{XValue, OValue}, {EmptyValue, EmptyValue}},
+			wantErr: ErrInvalidSize,
+		},
+		{
+			name: "when rows are not square should return error",
+			rows: [][]CellValue{
+				{EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+			},
+			wantErr: ErrInvalidRows,
+		},
+		{
+			name: "when rows have invalid value should return error",
+			rows: [][]CellValue{
+				{EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, CellValue(2), EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+			},
+			wantErr: ErrInvalidRows,
+		},
+		{
+			name: "when rows are valid should return board",
+			rows: [][]CellValue{
+				{XValue, EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, OValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue, XValue},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFromRows(tt.rows)
+			require.Equal(t, tt.wantErr, err)
+			if err != nil {
+				return
+			}
+			require.Equal(t, len(tt.rows), got.Size())
+			require.Equal(t, len(tt.rows), got.WinLength())
+			for i, row := range tt.rows {
+				for j, v := range row {
+					require.Equal(t, v, got.CellValue(MustNewCell(i, j)))
+				}
+			}
+		})
+	}
+}
+
+func TestBoard_NewCell(t *testing.T) {
+	b := MustNew(4, 3)
+	got, err := b.NewCell(3, 3)
+	require.NoError(t, err)
+	require.Equal(t, MustNewCell(3, 3), got)
+	_, err = b.NewCell(4, 0)
+	require.Equal(t, ErrInvalidCell, err)
+	_, err = b.NewCell(0, -1)
+	require.Equal(t, ErrInvalidCell, err)
+}
+
+func TestBoard_SetCellValue_OutsideBoard(t *testing.T) {
+	_, err := Board{}.SetCellValue(MustNewCell(3, 0))
+	require.Equal(t, ErrInvalidCell, err)
+}
+
+func TestBoard_Winner_Misere(t *testing.T) {
+	t.Run("when X completes a line in the misere variant should return O as the winner", func(t *testing.T) {
+		b := MustNewFromRows([][]CellValue{
+			{XValue, XValue, XValue},
+			{OValue, OValue, EmptyValue},
+			{EmptyValue, EmptyValue, EmptyValue},
+		}).WithVariant(Misere)
+		require.Equal(t, Misere, b.Variant())
+		w, ok := b.Winner()
+		require.True(t, ok)
+		require.Equal(t, OValue, w)
+		require.True(t, b.IsCompleted())
+	})
+	t.Run("when the variant is changed should keep the cells", func(t *testing.T) {
+		b := MustNew(5, 4).MustSetCellValue(MustNewCell(1, 1))
+		m := b.WithVariant(Misere)
+		require.Equal(t, b.CellValue(MustNewCell(1, 1)), m.CellValue(MustNewCell(1, 1)))
+		require.Equal(t, 4, m.WinLength())
+		require.NotEqual(t, b, m)
+		require.Equal(t, b, m.WithVariant(Classic))
+	})
+}
+
+func TestBoard_Winner_Wild(t *testing.T) {
+	t.Run("when the second player completes a line of X should return O as the winner", func(t *testing.T) {
+		b := MustNewFromRows([][]CellValue{
+			{XValue, XValue, EmptyValue},
+			{OValue, EmptyValue, EmptyValue},
+			{EmptyValue, EmptyValue, EmptyValue},
+		}).WithVariant(Wild)
+		b, err := b.PlaceCellValue(MustNewCell(0, 2), XValue)
+		require.NoError(t, err)
+		w, ok := b.Winner()
+		require.True(t, ok)
+		require.Equal(t, OValue, w)
+		require.True(t, b.IsCompleted())
+	})
+}
+
+func TestBoard_PlaceCellValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       Board
+		cell    Cell
+		value   CellValue
+		wantErr error
+	}{
+		{
+			name:  "when X places O on an empty board should set O",
+			b:     Board{},
+			cell:  MustNewCell(1, 1),
+			value: OValue,
+		},
+		{
+			name:    "when the value is empty should return error",
+			b:       Board{},
+			cell:    MustNewCell(1, 1),
+			value:   EmptyValue,
+			wantErr: ErrInvalidCellValue,
+		},
+		{
+			name:    "when the cell is outside the board should return error",
+			b:       Board{},
+			cell:    MustNewCell(3, 1),
+			value:   XValue,
+			wantErr: ErrInvalidCell,
+		},
+		{
+			name: "when the cell is not empty should return error",
+			b: MustNewFromRows([][]CellValue{
+				{XValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+			}),
+			cell:    MustNewCell(0, 0),
+			value:   XValue,
+			wantErr: ErrCellIsNotEmpty,
+		},
+		{
+			name: "when the board is completed should return error",
+			b: MustNewFromRows([][]CellValue{
+				{XValue, XValue, XValue},
+				{OValue, OValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+			}),
+			cell:    MustNewCell(2, 2),
+			value:   OValue,
+			wantErr: ErrGameIsOver,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.PlaceCellValue(tt.cell, tt.value)
+			require.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				require.Equal(t, tt.value, got.CellValue(tt.cell))
+				require.Equal(t, EmptyValue, tt.b.CellValue(tt.cell))
+			}
+		})
+	}
+}
+
+func TestBoard_Winner_Sized(t *testing.T) {
+	tests := []struct {
+		name          string
+		b             Board
+		wantCellValue CellValue
+		wantExist     bool
+	}{
+		{
+			name: "when 4x4 Board has only 3 'X' in row should return empty CellValue and false",
+			b: MustNewFromRows([][]CellValue{
+				{XValue, XValue, XValue, EmptyValue},
+				{OValue, OValue, OValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue, EmptyValue},
+			}),
+		},
+		{
+			name: "when 4x4 Board has 4 'O' in column should return 'O' CellValue and true",
+			b: MustNewFromRows([][]CellValue{
+				{XValue, XValue, OValue, EmptyValue},
+				{XValue, EmptyValue, OValue, EmptyValue},
+				{EmptyValue, XValue, OValue, EmptyValue},
+				{EmptyValue, XValue, OValue, EmptyValue},
+			}),
+			wantCellValue: OValue,
+			wantExist:     true,
+		},
+		{
+			name: "when 5x5 Board with win length 4 has 4 'X' in anti-diagonal should return 'X' CellValue and true",
+			b: withWinLength(MustNewFromRows([][]CellValue{
+				{EmptyValue, EmptyValue, EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue, EmptyValue, XValue},
+				{EmptyValue, OValue, EmptyValue, XValue, EmptyValue},
+				{EmptyValue, OValue, XValue, EmptyValue, EmptyValue},
+				{OValue, XValue, OValue, EmptyValue, EmptyValue},
+			}), 4),
+			wantCellValue: XValue,
+			wantExist:     true,
+		},
+		{
+			name: "when 5x5 Board with win length 4 has 4 'O' in diagonal not from corner should return 'O' CellValue and true",
+			b: withWinLength(MustNewFromRows([][]CellValue{
+				{EmptyValue, OValue, EmptyValue, EmptyValue, EmptyValue},
+				{XValue, EmptyValue, OValue, EmptyValue, EmptyValue},
+				{XValue, EmptyValue, EmptyValue, OValue, EmptyValue},
+				{XValue, EmptyValue, EmptyValue, EmptyValue, OValue},
+				{EmptyValue, EmptyValue, XValue, EmptyValue, EmptyValue},
+			}), 4),
+			wantCellValue: OValue,
+			wantExist:     true,
+		},
+		{
+			name: "when 5x5 Board with win length 4 has only 3 'X' in line should return empty CellValue and false",
+			b: withWinLength(MustNewFromRows([][]CellValue{
+				{XValue, XValue, XValue, OValue, XValue},
+				{OValue, EmptyValue, EmptyValue, EmptyValue, EmptyValue},
+				{OValue, EmptyValue, EmptyValue, EmptyValue, EmptyValue},
+				{OValue, EmptyValue, EmptyValue, EmptyValue, EmptyValue},
+				{XValue, EmptyValue, EmptyValue, EmptyValue, EmptyValue},
+			}), 4),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ex := tt.b.Winner()
+			require.Equal(t, tt.wantCellValue, got)
+			require.Equal(t, tt.wantExist, ex)
+		})
+	}
+}
+
+func TestBoard_Sized(t *testing.T) {
+	t.Run("when 4x4 Board has only one empty cell should find it and not be full", func(t *testing.T) {
+		b := MustNewFromRows([][]CellValue{
+			{XValue, OValue, XValue, OValue},
+			{XValue, OValue, XValue, OValue},
+			{OValue, XValue, OValue, XValue},
+			{OValue, XValue, OValue, EmptyValue},
+		})
+		require.False(t, b.IsFull())
+		require.Equal(t, &Cell{RowNumber: 3, ColumnNumber: 3}, b.FindFirstEmptyCell())
+		require.Equal(t, []Cell{MustNewCell(3, 3)}, b.EmptyCells())
+		b = b.MustSetCellValue(MustNewCell(3, 3))
+		require.True(t, b.IsFull())
+		require.Nil(t, b.FindFirstEmptyCell())
+	})
+	t.Run("should return corners, middle cell and side cells of 5x5 Board", func(t *testing.T) {
+		b := MustNew(5, 4)
+		require.Equal(t, []Cell{
+			MustNewCell(0, 0),
+			MustNewCell(0, 4),
+			MustNewCell(4, 0),
+			MustNewCell(4, 4),
+		}, b.Corners())
+		require.Equal(t, MustNewCell(2, 2), b.MidCell())
+		require.Len(t, b.SideCells(), 12)
+	})
+	t.Run("should return all lines of win length cells", func(t *testing.T) {
+		require.Len(t, Board{}.Lines(), 8)
+		require.Len(t, MustNew(4, 4).Lines(), 10)
+		// rows and columns: 2*5*2, diagonals and anti-diagonals: 2*2*2
+		require.Len(t, MustNew(5, 4).Lines(), 28)
+	})
+}
+
+func TestNewRectangular(t *testing.T) {
+	t.Run("should create the Connect Four board", func(t *testing.T) {
+		b, err := NewRectangular(6, 7, 4)
+		require.NoError(t, err)
+		require.Equal(t, 6, b.Rows())
+		require.Equal(t, 7, b.Columns())
+		require.Equal(t, 4, b.WinLength())
+		require.Len(t, b.EmptyCells(), 42)
+		require.True(t, b.Contains(MustNewCell(5, 6)))
+		require.False(t, b.Contains(MustNewCell(6, 0)))
+		// rows: 6*4, columns: 7*3, diagonals and anti-diagonals: 2*3*4
+		require.Len(t, b.Lines(), 69)
+		require.Equal(t, []Cell{MustNewCell(0, 0), MustNewCell(0, 6), MustNewCell(5, 0), MustNewCell(5, 6)}, b.Corners())
+	})
+	t.Run("when rows equal columns should be equal to the square board", func(t *testing.T) {
+		require.Equal(t, MustNew(5, 4), MustNewRectangular(5, 5, 4))
+		require.Equal(t, Board{}, MustNewRectangular(3, 3, 3))
+	})
+	t.Run("when the win length doesn't fit into the rows should return error", func(t *testing.T) {
+		_, err := NewRectangular(3, 7, 4)
+		require.Equal(t, ErrInvalidWinLength, err)
+	})
+	t.Run("when the rows are out of range should return error", func(t *testing.T) {
+		_, err := NewRectangular(16, 7, 4)
+		require.Equal(t, ErrInvalidSize, err)
+	})
+	t.Run("when a column is filled on a rectangular board should find the winner", func(t *testing.T) {
+		b := MustNewRectangular(6, 7, 4)
+		for _, c := range []Cell{
+			MustNewCell(5, 6), MustNewCell(5, 0),
+			MustNewCell(4, 6), MustNewCell(4, 0),
+			MustNewCell(3, 6), MustNewCell(3, 0),
+			MustNewCell(2, 6),
+		} {
+			b = b.MustSetCellValue(c)
+		}
+		w, ok := b.Winner()
+		require.True(t, ok)
+		require.Equal(t, XValue, w)
+	})
+}
+
+func TestBoard_Gravity(t *testing.T) {
+	b := MustNewRectangular(6, 7, 4).WithVariant(Gravity)
+	t.Run("when the board is empty should allow only the bottom row", func(t *testing.T) {
+		cells := b.PlayableCells()
+		require.Len(t, cells, 7)
+		for j, c := range cells {
+			require.Equal(t, MustNewCell(5, j), c)
+		}
+		_, err := b.SetCellValue(MustNewCell(4, 0))
+		require.Equal(t, ErrCellIsNotPlayable, err)
+		_, err = b.PlaceCellValue(MustNewCell(0, 0), OValue)
+		require.Equal(t, ErrCellIsNotPlayable, err)
+	})
+	t.Run("when a mark is dropped should stack the marks in the column", func(t *testing.T) {
+		next := b
+		for i := 5; i >= 0; i-- {
+			cell, err := next.DropCell(3)
+			require.NoError(t, err)
+			require.Equal(t, MustNewCell(i, 3), cell)
+			next = next.MustSetCellValue(cell)
+		}
+		_, err := next.DropCell(3)
+		require.Equal(t, ErrColumnIsFull, err)
+		require.Len(t, next.PlayableCells(), 6)
+		require.NotContains(t, next.PlayableCells(), MustNewCell(0, 3))
+	})
+	t.Run("when the column is outside the board should return error", func(t *testing.T) {
+		_, err := b.DropCell(7)
+		require.Equal(t, ErrInvalidCell, err)
+	})
+	t.Run("when the variant is not gravity should allow every empty cell", func(t *testing.T) {
+		require.Len(t, MustNewRectangular(6, 7, 4).PlayableCells(), 42)
+	})
+}
+
+func TestBoard_SprintWithHint(t *testing.T) {
+	b := MustNewFromRows([][]CellValue{
+		{XValue, EmptyValue, EmptyValue},
+		{EmptyValue, OValue, EmptyValue},
+		{EmptyValue, EmptyValue, EmptyValue},
+	})
+	tests := []struct {
+		name   string
+		cursor *Cell
+		hint   *Cell
+		want   string
+	}{
+		{
+			name:   "when cursor and hint are different cells should mark both",
+			cursor: &Cell{RowNumber: 0, ColumnNumber: 0},
+			hint:   &Cell{RowNumber: 2, ColumnNumber: 2},
+			want:   "[X] |  -  |  - \n -  |  O  |  - \n -  |  -  | (-)\n",
+		},
+		{
+			name:   "when cursor is on the hint should mark the cell as both",
+			cursor: &Cell{RowNumber: 2, ColumnNumber: 2},
+			hint:   &Cell{RowNumber: 2, ColumnNumber: 2},
+			want:   " X  |  -  |  - \n -  |  O  |  - \n -  |  -  | {-}\n",
+		},
+		{
+			name: "when there is no hint should render like Sprint",
+			want: " X  |  -  |  - \n -  |  O  |  - \n -  |  -  |  - \n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, b.SprintWithHint(tt.cursor, tt.hint))
+			if tt.hint == nil {
+				require.Equal(t, tt.want, b.Sprint(tt.cursor))
+			}
+		})
+	}
+}
@@ -4,7 +4,7 @@ import "errors"
 
 var (
 	// ErrInvalidCell is returned when a cell is invalid.
-	ErrInvalidCell = errors.New("invalid Cell, Cell must be between 0 and 2, inclusive")
+	ErrInvalidCell = errors.New("invalid Cell, Cell must be inside the board")
 )
 
 // Cell represents a cell in the board.
@@ -13,9 +13,10 @@ type Cell struct {
 	ColumnNumber int
 }
 
-// NewCell creates a Cell.
+// NewCell creates a Cell that fits into a board of the MaxSize.
+// Use Board.NewCell to validate the cell against the particular board.
 func NewCell(row, coll int) (Cell, error) {
-	if row < 0 || coll < 0 || row > boardSize-1 || coll > boardSize-1 {
+	if row < 0 || coll < 0 || row > MaxSize-1 || coll > MaxSize-1 {
 		return Cell{}, ErrInvalidCell
 	}
 	return Cell{
@@ -30,13 +30,13 @@ func TestNewCell(t *testing.T) {
 		},
 		{
 			name:    "big rowN",
-			args:    args{rowN: boardSize, collN: 0},
+			args:    args{rowN: MaxSize, collN: 0},
 			want:    Cell{},
 			wantErr: ErrInvalidCell,
 		},
 		{
 			name:    "big collN",
-			args:    args{rowN: 0, collN: boardSize},
+			args:    args{rowN: 0, collN: MaxSize},
 			want:    Cell{},
 			wantErr: ErrInvalidCell,
 		},
@@ -0,0 +1,175 @@
+package board
+
+import "errors"
+
+// ErrInvalidSymmetry is returned when a symmetry would break the rules of the board variant,
+// e.g. a rotation of a gravity board leaves marks floating.
+var ErrInvalidSymmetry = errors.New("invalid symmetry, it doesn't keep the rules of the board variant")
+
+// Symmetry is a rotation or a reflection of the board, it maps every line to a line,
+// so the transformed position is equivalent to the original one.
+type Symmetry int
+
+const (
+	// Identity keeps the board as it is.
+	Identity Symmetry = iota
+	// Rotate90 rotates the board by 90 degrees clockwise.
+	Rotate90
+	// Rotate180 rotates the board by 180 degrees.
+	Rotate180
+	// Rotate270 rotates the board by 270 degrees clockwise.
+	Rotate270
+	// ReflectHorizontal reflects the board in the horizontal axis: the top row becomes the bottom one.
+	ReflectHorizontal
+	// ReflectVertical reflects the board in the vertical axis: the left column becomes the right one.
+	ReflectVertical
+	// ReflectDiagonal reflects the board in the main diagonal: rows become columns.
+	ReflectDiagonal
+	// ReflectAntiDiagonal reflects the board in the anti-diagonal.
+	ReflectAntiDiagonal
+)
+
+// Symmetries are all symmetries of the square board.
+var Symmetries = []Symmetry{
+	Identity, Rotate90, Rotate180, Rotate270,
+	ReflectHorizontal, ReflectVertical, ReflectDiagonal, ReflectAntiDiagonal,
+}
+
+var symmetryNames = map[Symmetry]string{
+	Identity:            "identity",
+	Rotate90:            "rotate 90",
+	Rotate180:           "rotate 180",
+	Rotate270:           "rotate 270",
+	ReflectHorizontal:   "reflect horizontal",
+	ReflectVertical:     "reflect vertical",
+	ReflectDiagonal:     "reflect diagonal",
+	ReflectAntiDiagonal: "reflect anti-diagonal",
+}
+
+// String returns the string representation of the Symmetry.
+func (s Symmetry) String() string {
+	return symmetryNames[s]
+}
+
+// Inverse returns the symmetry that undoes the symmetry.
+func (s Symmetry) Inverse() Symmetry {
+	switch s {
+	case Rotate90:
+		return Rotate270
+	case Rotate270:
+		return Rotate90
+	}
+	return s
+}
+
+// swapsDimensions returns true if the symmetry turns rows into columns.
+func (s Symmetry) swapsDimensions() bool {
+	switch s {
+	case Rotate90, Rotate270, ReflectDiagonal, ReflectAntiDiagonal:
+		return true
+	}
+	return false
+}
+
+// mapCell returns the cell of the board with the given dimensions after the symmetry.
+func (s Symmetry) mapCell(c Cell, rows, columns int) Cell {
+	r, col := c.RowNumber, c.ColumnNumber
+	switch s {
+	case Rotate90:
+		return Cell{RowNumber: col, ColumnNumber: rows - 1 - r}
+	case Rotate180:
+		return Cell{RowNumber: rows - 1 - r, ColumnNumber: columns - 1 - col}
+	case Rotate270:
+		return Cell{RowNumber: columns - 1 - col, ColumnNumber: r}
+	case ReflectHorizontal:
+		return Cell{RowNumber: rows - 1 - r, ColumnNumber: col}
+	case ReflectVertical:
+		return Cell{RowNumber: r, ColumnNumber: columns - 1 - col}
+	case ReflectDiagonal:
+		return Cell{RowNumber: col, ColumnNumber: r}
+	case ReflectAntiDiagonal:
+		return Cell{RowNumber: columns - 1 - col, ColumnNumber: rows - 1 - r}
+	}
+	return c
+}
+
+// Symmetries returns the symmetries that map the board onto a board of the same dimensions and rules:
+// all eight of a square board, four of a rectangular one, and in the gravity variant only
+// the identity and the reflection in the vertical axis, as marks must stay at the bottom.
+func (b Board) Symmetries() []Symmetry {
+	if b.variant == Gravity {
+		return []Symmetry{Identity, ReflectVertical}
+	}
+	if b.Rows() != b.Columns() {
+		return []Symmetry{Identity, Rotate180, ReflectHorizontal, ReflectVertical}
+	}
+	return Symmetries
+}
+
+// Transform returns the board after the symmetry, rotations and diagonal reflections
+// of a rectangular board swap its rows and columns.
+func (b Board) Transform(s Symmetry) (Board, error) {
+	if b.variant == Gravity && s != Identity && s != ReflectVertical {
+		return Board{}, ErrInvalidSymmetry
+	}
+	rows, columns := b.Rows(), b.Columns()
+	r := b
+	if s.swapsDimensions() && rows != columns {
+		t, err := NewRectangular(columns, rows, b.WinLength())
+		if err != nil {
+			return Board{}, err
+		}
+		r = t.WithVariant(b.variant)
+	}
+	r.cells = [MaxSize][MaxSize]int8{}
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			c := s.mapCell(Cell{RowNumber: i, ColumnNumber: j}, rows, columns)
+			r.cells[c.RowNumber][c.ColumnNumber] = b.cells[i][j]
+		}
+	}
+	return r, nil
+}
+
+// MustTransform is like Transform but panics if the symmetry is invalid for the board.
+func (b Board) MustTransform(s Symmetry) Board {
+	r, err := b.Transform(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// TransformCell returns the cell of the transformed board the cell of the board is mapped to by the symmetry.
+func (b Board) TransformCell(s Symmetry, c Cell) Cell {
+	return s.mapCell(c, b.Rows(), b.Columns())
+}
+
+// Canonical returns the canonical form of the board: the smallest of the boards it is mapped to
+// by its symmetries, comparing cells in row-major order. Equivalent positions have equal canonical forms.
+// It also returns the symmetry that maps the board to its canonical form, so cells are mapped by
+// b.TransformCell(s, cell) to the canonical board and by canonical.TransformCell(s.Inverse(), cell) back.
+func (b Board) Canonical() (Board, Symmetry) {
+	best, bestSymmetry := b, Identity
+	for _, s := range b.Symmetries()[1:] {
+		t := b.MustTransform(s)
+		if t.less(best) {
+			best, bestSymmetry = t, s
+		}
+	}
+	return best, bestSymmetry
+}
+
+// less returns true if the cells of the board precede the cells of the other board of the same dimensions
+// in row-major order.
+func (b Board) less(other Board) bool {
+	rows, columns := b.Rows(), b.Columns()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			if b.cells[i][j] != other.cells[i][j] {
+				return b.cells[i][j] < other.cells[i][j]
+			}
+		}
+	}
+	return false
+}
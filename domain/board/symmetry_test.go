@@ -0,0 +1,138 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_Transform(t *testing.T) {
+	b := MustNewFromRows([][]CellValue{
+		{XValue, OValue, EmptyValue},
+		{EmptyValue, EmptyValue, EmptyValue},
+		{EmptyValue, EmptyValue, EmptyValue},
+	})
+	tests := []struct {
+		name     string
+		symmetry Symmetry
+		want     [][]CellValue
+	}{
+		{
+			name:     "when the symmetry is rotate 90 should rotate the board clockwise",
+			symmetry: Rotate90,
+			want: [][]CellValue{
+				{EmptyValue, EmptyValue, XValue},
+				{EmptyValue, EmptyValue, OValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+			},
+		},
+		{
+			name:     "when the symmetry is rotate 180 should turn the board upside down",
+			symmetry: Rotate180,
+			want: [][]CellValue{
+				{EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, OValue, XValue},
+			},
+		},
+		{
+			name:     "when the symmetry is reflect vertical should mirror the columns",
+			symmetry: ReflectVertical,
+			want: [][]CellValue{
+				{EmptyValue, OValue, XValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+			},
+		},
+		{
+			name:     "when the symmetry is reflect diagonal should turn rows into columns",
+			symmetry: ReflectDiagonal,
+			want: [][]CellValue{
+				{XValue, EmptyValue, EmptyValue},
+				{OValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, EmptyValue},
+			},
+		},
+		{
+			name:     "when the symmetry is reflect anti-diagonal should mirror in the anti-diagonal",
+			symmetry: ReflectAntiDiagonal,
+			want: [][]CellValue{
+				{EmptyValue, EmptyValue, EmptyValue},
+				{EmptyValue, EmptyValue, OValue},
+				{EmptyValue, EmptyValue, XValue},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := b.Transform(tt.symmetry)
+			require.NoError(t, err)
+			require.Equal(t, MustNewFromRows(tt.want), got)
+			require.Equal(t, b, got.MustTransform(tt.symmetry.Inverse()))
+			for _, c := range []Cell{MustNewCell(0, 0), MustNewCell(0, 1)} {
+				require.Equal(t, b.CellValue(c), got.CellValue(b.TransformCell(tt.symmetry, c)))
+			}
+		})
+	}
+}
+
+func TestBoard_Transform_Rectangular(t *testing.T) {
+	b := MustNewRectangular(3, 4, 3).MustSetCellValue(MustNewCell(0, 3))
+	t.Run("when the symmetry rotates should swap rows and columns", func(t *testing.T) {
+		got := b.MustTransform(Rotate90)
+		require.Equal(t, 4, got.Rows())
+		require.Equal(t, 3, got.Columns())
+		require.Equal(t, XValue, got.CellValue(MustNewCell(3, 2)))
+		require.Equal(t, b, got.MustTransform(Rotate270))
+	})
+	t.Run("should keep the dimensions by its own symmetries", func(t *testing.T) {
+		require.Len(t, b.Symmetries(), 4)
+		for _, s := range b.Symmetries() {
+			got := b.MustTransform(s)
+			require.Equal(t, b.Rows(), got.Rows())
+			require.Equal(t, b.Columns(), got.Columns())
+		}
+	})
+}
+
+func TestBoard_Transform_Gravity(t *testing.T) {
+	b := MustNewRectangular(6, 7, 4).WithVariant(Gravity).MustSetCellValue(MustNewCell(5, 0))
+	t.Run("when the symmetry is reflect vertical should keep marks at the bottom", func(t *testing.T) {
+		got, err := b.Transform(ReflectVertical)
+		require.NoError(t, err)
+		require.Equal(t, XValue, got.CellValue(MustNewCell(5, 6)))
+		require.Equal(t, Gravity, got.Variant())
+	})
+	t.Run("when the symmetry would make marks float should return error", func(t *testing.T) {
+		_, err := b.Transform(Rotate180)
+		require.ErrorIs(t, err, ErrInvalidSymmetry)
+	})
+}
+
+func TestBoard_Canonical(t *testing.T) {
+	t.Run("when positions are equivalent should have the same canonical form", func(t *testing.T) {
+		corners := []Cell{MustNewCell(0, 0), MustNewCell(0, 2), MustNewCell(2, 0), MustNewCell(2, 2)}
+		want, _ := Board{}.MustSetCellValue(corners[0]).Canonical()
+		for _, c := range corners[1:] {
+			got, _ := Board{}.MustSetCellValue(c).Canonical()
+			require.Equal(t, want, got)
+		}
+		center, _ := Board{}.MustSetCellValue(MustNewCell(1, 1)).Canonical()
+		require.NotEqual(t, want, center)
+	})
+	t.Run("should map cells to the canonical form and back", func(t *testing.T) {
+		b := Board{}.MustSetCellValue(MustNewCell(2, 1)).MustSetCellValue(MustNewCell(0, 0))
+		canonical, s := b.Canonical()
+		require.Equal(t, canonical, b.MustTransform(s))
+		for _, c := range b.EmptyCells() {
+			mapped := b.TransformCell(s, c)
+			require.True(t, canonical.IsEmptyCell(mapped))
+			require.Equal(t, c, canonical.TransformCell(s.Inverse(), mapped))
+		}
+	})
+	t.Run("when the board is empty should be its own canonical form", func(t *testing.T) {
+		got, s := Board{}.Canonical()
+		require.Equal(t, Board{}, got)
+		require.Equal(t, Identity, s)
+	})
+}
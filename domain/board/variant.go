@@ -0,0 +1,50 @@
+package board
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidVariant is returned when a variant name is unknown.
+var ErrInvalidVariant = errors.New("invalid variant, it must be classic, misere, gravity or wild")
+
+// Variant is the rule set that decides the winner of a board.
+type Variant int
+
+const (
+	// Classic is the variant where the player who completes a line wins.
+	Classic Variant = iota
+	// Misere is the reverse variant where the player who completes a line loses.
+	Misere
+	// Gravity is the Connect Four like variant where marks fall to the lowest empty cell of the column.
+	Gravity
+	// Wild is the variant where either player may place X or O and the player who completes a line wins.
+	// The turn is tracked by the count of full cells: the first player moves when it is even.
+	Wild
+)
+
+// Variants are all variants.
+var Variants = []Variant{Classic, Misere, Gravity, Wild}
+
+// String returns the string representation of the Variant.
+func (v Variant) String() string {
+	switch v {
+	case Misere:
+		return "misere"
+	case Gravity:
+		return "gravity"
+	case Wild:
+		return "wild"
+	}
+	return "classic"
+}
+
+// ParseVariant returns the variant by its case-insensitive name.
+func ParseVariant(s string) (Variant, error) {
+	for _, v := range Variants {
+		if strings.EqualFold(v.String(), s) {
+			return v, nil
+		}
+	}
+	return Classic, ErrInvalidVariant
+}
@@ -0,0 +1,29 @@
+package board
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVariant(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Variant
+		wantErr error
+	}{
+		{name: "when the name is classic should return Classic", s: "classic", want: Classic},
+		{name: "when the name is in upper case should return the variant", s: "MISERE", want: Misere},
+		{name: "when the name is gravity should return Gravity", s: "gravity", want: Gravity},
+		{name: "when the name is wild should return Wild", s: "wild", want: Wild},
+		{name: "when the name is unknown should return error", s: "suicide", wantErr: ErrInvalidVariant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVariant(tt.s)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
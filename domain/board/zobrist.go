@@ -0,0 +1,79 @@
+package board
+
+import "math/rand"
+
+// zobristSeed seeds the Zobrist table, it is fixed so hashes are stable across processes and can be stored.
+const zobristSeed = 0x7a6f6272697374
+
+// Hash is the Zobrist hash of a position: the XOR of the random keys of every placed mark
+// and of the board dimensions and variant.
+// Equal positions have equal hashes, different positions collide with the probability about 2^-64.
+// A move updates the hash by a single XOR, the same XOR undoes it.
+type Hash uint64
+
+// zobrist are the random keys of the Zobrist hashing.
+var zobrist = newZobristTable(zobristSeed)
+
+// zobristTable holds the random keys of every mark on every cell, of the board dimensions and of the variants.
+type zobristTable struct {
+	// cells are indexed by the row, the column and the mark: 0 for X and 1 for O.
+	cells    [MaxSize][MaxSize][2]Hash
+	shapes   [MaxSize + 1][MaxSize + 1][MaxSize + 1]Hash
+	variants [Wild + 1]Hash
+}
+
+// newZobristTable returns the table of random keys generated from the seed.
+func newZobristTable(seed int64) *zobristTable {
+	rnd := rand.New(rand.NewSource(seed))
+	t := &zobristTable{}
+	for i := range t.cells {
+		for j := range t.cells[i] {
+			for k := range t.cells[i][j] {
+				t.cells[i][j][k] = Hash(rnd.Uint64())
+			}
+		}
+	}
+	for i := range t.shapes {
+		for j := range t.shapes[i] {
+			for k := range t.shapes[i][j] {
+				t.shapes[i][j][k] = Hash(rnd.Uint64())
+			}
+		}
+	}
+	for i := range t.variants {
+		t.variants[i] = Hash(rnd.Uint64())
+	}
+	return t
+}
+
+// key returns the key of the mark on the cell.
+func (t *zobristTable) key(cell Cell, value CellValue) Hash {
+	if value == OValue {
+		return t.cells[cell.RowNumber][cell.ColumnNumber][1]
+	}
+	return t.cells[cell.RowNumber][cell.ColumnNumber][0]
+}
+
+// Hash returns the Zobrist hash of the board.
+func (b Board) Hash() Hash {
+	h := zobrist.shapes[b.Rows()][b.Columns()][b.WinLength()] ^ zobrist.variants[b.variant]
+	rows, columns := b.Rows(), b.Columns()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < columns; j++ {
+			if v := CellValue(b.cells[i][j]); !v.IsEmpty() {
+				h ^= zobrist.key(Cell{RowNumber: i, ColumnNumber: j}, v)
+			}
+		}
+	}
+	return h
+}
+
+// Play returns the hash of the position after the value is placed on the cell.
+func (h Hash) Play(cell Cell, value CellValue) Hash {
+	return h ^ zobrist.key(cell, value)
+}
+
+// Undo returns the hash of the position before the value was placed on the cell.
+func (h Hash) Undo(cell Cell, value CellValue) Hash {
+	return h ^ zobrist.key(cell, value)
+}
@@ -0,0 +1,76 @@
+package board
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoard_Hash(t *testing.T) {
+	t.Run("when positions are equal should return equal hashes", func(t *testing.T) {
+		first := Board{}.MustSetCellValue(MustNewCell(0, 0)).MustSetCellValue(MustNewCell(1, 1)).MustSetCellValue(MustNewCell(2, 2))
+		second := Board{}.MustSetCellValue(MustNewCell(2, 2)).MustSetCellValue(MustNewCell(1, 1)).MustSetCellValue(MustNewCell(0, 0))
+		require.Equal(t, first.Hash(), second.Hash())
+	})
+	tests := []struct {
+		name   string
+		first  Board
+		second Board
+	}{
+		{
+			name:   "when marks differ should return different hashes",
+			first:  Board{}.MustSetCellValue(MustNewCell(0, 0)),
+			second: Board{}.MustSetCellValue(MustNewCell(0, 1)),
+		},
+		{
+			name:   "when variants differ should return different hashes",
+			first:  Board{},
+			second: Board{}.WithVariant(Misere),
+		},
+		{
+			name:   "when win lengths differ should return different hashes",
+			first:  MustNew(4, 3),
+			second: MustNew(4, 4),
+		},
+		{
+			name:   "when dimensions are swapped should return different hashes",
+			first:  MustNewRectangular(3, 4, 3),
+			second: MustNewRectangular(4, 3, 3),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NotEqual(t, tt.first.Hash(), tt.second.Hash())
+		})
+	}
+}
+
+func TestHash_Play(t *testing.T) {
+	b := MustNew(5, 4)
+	h := b.Hash()
+	rnd := rand.New(rand.NewSource(1))
+	var played []Cell
+	for !b.IsCompleted() {
+		cells := b.PlayableCells()
+		cell := cells[rnd.Intn(len(cells))]
+		h = h.Play(cell, b.CurrentTurnCellValue())
+		b = b.MustSetCellValue(cell)
+		played = append(played, cell)
+		require.Equal(t, b.Hash(), h)
+		require.Equal(t, b.Hash(), b.Bitboard().Hash())
+	}
+	t.Run("should undo the moves back to the empty board", func(t *testing.T) {
+		for k := len(played) - 1; k >= 0; k-- {
+			h = h.Undo(played[k], b.CellValue(played[k]))
+		}
+		require.Equal(t, MustNew(5, 4).Hash(), h)
+	})
+	t.Run("should update the bitboard hash on every move", func(t *testing.T) {
+		bb := MustNew(5, 4).Bitboard()
+		for _, cell := range played {
+			bb = bb.Play(bb.Index(cell))
+		}
+		require.Equal(t, b.Hash(), bb.Hash())
+	})
+}
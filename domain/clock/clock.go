@@ -0,0 +1,189 @@
+// Package clock implements chess-style game clocks: every player has their own time
+// that runs only during their moves, the player whose time is up loses the game.
+package clock
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"tictactoe/domain/board"
+)
+
+// ErrInvalidTimeControl is returned when a time control has negative durations or no limit.
+var ErrInvalidTimeControl = errors.New("invalid time control, durations must not be negative and the base time or the per-move limit must be set")
+
+// TimeControl defines how much time the players have.
+type TimeControl struct {
+	// Base is the time of every player for the whole game, zero means the game time is not limited.
+	Base time.Duration
+	// Increment is added to the time of the player after every move.
+	Increment time.Duration
+	// PerMove limits the time of every move, zero means moves are not limited.
+	PerMove time.Duration
+}
+
+// SuddenDeath returns the time control where every player has the base time for the whole game.
+func SuddenDeath(base time.Duration) TimeControl {
+	return TimeControl{Base: base}
+}
+
+// Fischer returns the time control where the increment is added to the base time after every move.
+func Fischer(base, increment time.Duration) TimeControl {
+	return TimeControl{Base: base, Increment: increment}
+}
+
+// PerMove returns the time control where every move is limited and the game is not.
+func PerMove(limit time.Duration) TimeControl {
+	return TimeControl{PerMove: limit}
+}
+
+// Validate returns an error if the time control is invalid.
+func (tc TimeControl) Validate() error {
+	if tc.Base < 0 || tc.Increment < 0 || tc.PerMove < 0 || (tc.Base == 0 && tc.PerMove == 0) {
+		return ErrInvalidTimeControl
+	}
+	return nil
+}
+
+// String returns the string representation of the TimeControl, e.g. "3m0s+2s" or "10s per move".
+func (tc TimeControl) String() string {
+	var parts []string
+	if tc.Base > 0 {
+		s := tc.Base.String()
+		if tc.Increment > 0 {
+			s += "+" + tc.Increment.String()
+		}
+		parts = append(parts, s)
+	}
+	if tc.PerMove > 0 {
+		parts = append(parts, tc.PerMove.String()+" per move")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Clock is the clock of a game, the time of the side to move is running.
+// Times are passed explicitly, so the clock is deterministic.
+type Clock struct {
+	control TimeControl
+	// remaining is the game time left before the current move of every side: X at 0 and O at 1.
+	remaining [2]time.Duration
+	side      board.CellValue
+	// started is the start of the current move.
+	started time.Time
+	stopped bool
+	// stoppedAt is the time the clock was stopped.
+	stoppedAt time.Time
+}
+
+// New returns a new clock running for the side from the time.
+func New(tc TimeControl, side board.CellValue, at time.Time) (*Clock, error) {
+	if err := tc.Validate(); err != nil {
+		return nil, err
+	}
+	if side != board.XValue && side != board.OValue {
+		return nil, board.ErrInvalidCellValue
+	}
+	return &Clock{
+		control:   tc,
+		remaining: [2]time.Duration{tc.Base, tc.Base},
+		side:      side,
+		started:   at,
+	}, nil
+}
+
+// Control returns the time control of the clock.
+func (c *Clock) Control() TimeControl {
+	return c.control
+}
+
+// Side returns the side whose time is running.
+func (c *Clock) Side() board.CellValue {
+	return c.side
+}
+
+// Press ends the move of the running side at the time: its time is charged,
+// the increment is added and the time of the opponent starts running.
+func (c *Clock) Press(at time.Time) {
+	if c.stopped {
+		return
+	}
+	i := index(c.side)
+	c.remaining[i] -= at.Sub(c.started)
+	c.remaining[i] += c.control.Increment
+	c.side = -c.side
+	c.started = at
+}
+
+// Stop stops the clock at the time, e.g. when the game is over.
+func (c *Clock) Stop(at time.Time) {
+	if c.stopped {
+		return
+	}
+	c.stopped = true
+	c.stoppedAt = at
+}
+
+// Remaining returns the time the side has at the time before its flag falls.
+// The time of the side that is not running is the one it will have at the start of its move.
+func (c *Clock) Remaining(side board.CellValue, at time.Time) time.Duration {
+	if c.stopped {
+		at = c.stoppedAt
+	}
+	var elapsed time.Duration
+	if side == c.side {
+		elapsed = at.Sub(c.started)
+	}
+	left := time.Duration(-1)
+	if c.control.Base > 0 {
+		left = c.remaining[index(side)] - elapsed
+	}
+	if c.control.PerMove > 0 && (left < 0 || c.control.PerMove-elapsed < left) {
+		left = c.control.PerMove - elapsed
+	}
+	return max(left, 0)
+}
+
+// Deadline returns the time the flag of the running side falls unless it moves.
+func (c *Clock) Deadline() time.Time {
+	return c.started.Add(c.Remaining(c.side, c.started))
+}
+
+// Flagged returns the running side if its time is up at the time.
+func (c *Clock) Flagged(at time.Time) (board.CellValue, bool) {
+	if c.stopped {
+		at = c.stoppedAt
+	}
+	if at.Before(c.Deadline()) {
+		return board.EmptyValue, false
+	}
+	return c.side, true
+}
+
+// Sprint returns the remaining times of both sides at the time, the running side is marked by the arrow.
+func (c *Clock) Sprint(at time.Time) string {
+	var parts []string
+	for _, side := range []board.CellValue{board.XValue, board.OValue} {
+		s := fmt.Sprintf("%s %s", side, Format(c.Remaining(side, at)))
+		if side == c.side && !c.stopped {
+			s += " <"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Format returns the duration as minutes, seconds and tenths of a second, e.g. "2:05.3".
+func Format(d time.Duration) string {
+	d = d.Truncate(100 * time.Millisecond)
+	return fmt.Sprintf("%d:%02d.%d", int(d.Minutes()), int(d.Seconds())%60, int(d.Milliseconds()/100)%10)
+}
+
+// index returns the index of the side.
+func index(side board.CellValue) int {
+	if side == board.XValue {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,86 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+)
+
+var start = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestTimeControl_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tc      TimeControl
+		wantErr error
+	}{
+		{name: "when the base time is set should be valid", tc: SuddenDeath(time.Minute)},
+		{name: "when only the per-move limit is set should be valid", tc: PerMove(10 * time.Second)},
+		{name: "when nothing is limited should return error", tc: TimeControl{Increment: time.Second}, wantErr: ErrInvalidTimeControl},
+		{name: "when a duration is negative should return error", tc: Fischer(time.Minute, -time.Second), wantErr: ErrInvalidTimeControl},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantErr, tt.tc.Validate())
+		})
+	}
+}
+
+func TestTimeControl_String(t *testing.T) {
+	require.Equal(t, "1m0s", SuddenDeath(time.Minute).String())
+	require.Equal(t, "3m0s+2s", Fischer(3*time.Minute, 2*time.Second).String())
+	require.Equal(t, "10s per move", PerMove(10*time.Second).String())
+}
+
+func TestClock(t *testing.T) {
+	t.Run("when the clock is pressed should charge the running side and add the increment", func(t *testing.T) {
+		c, err := New(Fischer(time.Minute, 2*time.Second), board.XValue, start)
+		require.NoError(t, err)
+		c.Press(start.Add(10 * time.Second))
+		require.Equal(t, board.OValue, c.Side())
+		require.Equal(t, 52*time.Second, c.Remaining(board.XValue, start.Add(20*time.Second)))
+		require.Equal(t, 50*time.Second, c.Remaining(board.OValue, start.Add(20*time.Second)))
+		require.Equal(t, start.Add(70*time.Second), c.Deadline())
+	})
+	t.Run("when the time of the running side is up should flag it", func(t *testing.T) {
+		c, err := New(SuddenDeath(time.Minute), board.XValue, start)
+		require.NoError(t, err)
+		_, flagged := c.Flagged(start.Add(59 * time.Second))
+		require.False(t, flagged)
+		side, flagged := c.Flagged(start.Add(time.Minute))
+		require.True(t, flagged)
+		require.Equal(t, board.XValue, side)
+		require.Zero(t, c.Remaining(board.XValue, start.Add(2*time.Minute)))
+	})
+	t.Run("when the move is limited should flag the side after the limit", func(t *testing.T) {
+		c, err := New(TimeControl{Base: time.Minute, PerMove: 10 * time.Second}, board.XValue, start)
+		require.NoError(t, err)
+		c.Press(start.Add(5 * time.Second))
+		require.Equal(t, 10*time.Second, c.Remaining(board.XValue, start.Add(6*time.Second)))
+		require.Equal(t, start.Add(15*time.Second), c.Deadline())
+		side, flagged := c.Flagged(start.Add(15 * time.Second))
+		require.True(t, flagged)
+		require.Equal(t, board.OValue, side)
+	})
+	t.Run("when the clock is stopped should freeze the times", func(t *testing.T) {
+		c, err := New(SuddenDeath(time.Minute), board.XValue, start)
+		require.NoError(t, err)
+		c.Stop(start.Add(10 * time.Second))
+		require.Equal(t, 50*time.Second, c.Remaining(board.XValue, start.Add(time.Hour)))
+		_, flagged := c.Flagged(start.Add(time.Hour))
+		require.False(t, flagged)
+	})
+	t.Run("when the time control is invalid should return error", func(t *testing.T) {
+		_, err := New(TimeControl{}, board.XValue, start)
+		require.ErrorIs(t, err, ErrInvalidTimeControl)
+	})
+}
+
+func TestClock_Sprint(t *testing.T) {
+	c, err := New(SuddenDeath(2*time.Minute), board.XValue, start)
+	require.NoError(t, err)
+	require.Equal(t, "X 1:54.7 < | O 2:00.0", c.Sprint(start.Add(5*time.Second+250*time.Millisecond)))
+}
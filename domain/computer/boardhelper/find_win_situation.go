@@ -2,61 +2,57 @@ package boardhelper
 
 import "tictactoe/domain/board"
 
-// FindWinSituationsFor returns any cell that will create boardSize-1 in line and the count of win situations.
+// FindWinSituationsFor returns any cell that will create WinLength in line and the count of win situations.
+// A win situation is a board line with WinLength-1 cells of the value and one empty cell.
 func FindWinSituationsFor(b board.Board, value board.CellValue) (winCell *board.Cell, count int) {
-	boardSize := len(b)
-	rowsSum := make([]int, boardSize)
-	colsSum := make([]int, boardSize)
-	diag1Sum := 0
-	diag2Sum := 0
-	neededCountInLine := boardSize - 1
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			rowsSum[i] += int(b[i][j])
-			colsSum[i] += int(b[j][i])
-			if i == j {
-				diag1Sum += int(b[i][j])
-			}
-			if i+j == boardSize-1 {
-				diag2Sum += int(b[i][j])
+	for _, line := range b.Lines() {
+		valueCount := 0
+		var emptyCell *board.Cell
+		for _, cell := range line {
+			switch b.CellValue(cell) {
+			case value:
+				valueCount++
+			case board.EmptyValue:
+				c := cell
+				emptyCell = &c
 			}
 		}
-		if rowsSum[i] == int(value)*neededCountInLine {
+		if valueCount == len(line)-1 && emptyCell != nil {
 			count++
-			// Find the empty cell
-			for j := 0; j < boardSize; j++ {
-				if b[i][j].IsEmpty() {
-					winCell = &board.Cell{RowNumber: i, ColumnNumber: j}
-				}
-			}
-		}
-		if colsSum[i] == int(value)*neededCountInLine {
-			count++
-			// Find the empty cell
-			for j := 0; j < boardSize; j++ {
-				if b[j][i].IsEmpty() {
-					winCell = &board.Cell{RowNumber: j, ColumnNumber: i}
-				}
-			}
+			winCell = emptyCell
 		}
 	}
-	if diag1Sum == int(value)*neededCountInLine {
-		count++
-		// Find the empty cell
-		for j := 0; j < boardSize; j++ {
-			if b[j][j].IsEmpty() {
-				winCell = &board.Cell{RowNumber: j, ColumnNumber: j}
+	return winCell, count
+}
+
+// FindWinCellsFor returns the distinct cells that will create WinLength in line for the value.
+func FindWinCellsFor(b board.Board, value board.CellValue) []board.Cell {
+	var cells []board.Cell
+	for _, line := range b.Lines() {
+		valueCount := 0
+		var emptyCell *board.Cell
+		for _, cell := range line {
+			switch b.CellValue(cell) {
+			case value:
+				valueCount++
+			case board.EmptyValue:
+				c := cell
+				emptyCell = &c
 			}
 		}
+		if valueCount == len(line)-1 && emptyCell != nil && !containsCell(cells, *emptyCell) {
+			cells = append(cells, *emptyCell)
+		}
 	}
-	if diag2Sum == int(value)*neededCountInLine {
-		count++
-		// Find the empty cell
-		for j := 0; j < boardSize; j++ {
-			if b[j][boardSize-1-j].IsEmpty() {
-				winCell = &board.Cell{RowNumber: j, ColumnNumber: boardSize - 1 - j}
-			}
+	return cells
+}
+
+// containsCell returns true if the cells contain the cell.
+func containsCell(cells []board.Cell, cell board.Cell) bool {
+	for _, c := range cells {
+		if c == cell {
+			return true
 		}
 	}
-	return winCell, count
+	return false
 }
@@ -18,98 +18,98 @@ func TestFindWinSituationFor(t *testing.T) {
 	}{
 		{
 			name: "For X turn: when Board don't have 2 'X in lines should return nil and 0",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.OValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			value:     board.XValue,
 			wantCount: 0,
 		},
 		{
 			name: "For X turn: when Board has 2 'X' in column should return the empty in this line cell and 1",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.OValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			value:       board.XValue,
 			wantWinCell: board.MustNewCell(1, 0),
 			wantCount:   1,
 		},
 		{
 			name: "For X turn: when Board has 2 'X' in row should return the empty in this line cell and 1",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.OValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.XValue, board.EmptyValue},
-			},
+			}),
 			value:       board.XValue,
 			wantWinCell: board.MustNewCell(2, 2),
 			wantCount:   1,
 		},
 		{
 			name: "For X turn: when Board has 2 'X' in diagonal should return the empty in this line cell and 1",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.XValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			value:       board.XValue,
 			wantWinCell: board.MustNewCell(0, 2),
 			wantCount:   1,
 		},
 		{
 			name: "For O turn: when Board has 2 'O' in column should return the empty in this line cell and 1",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.XValue, board.XValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.OValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			value:       board.OValue,
 			wantWinCell: board.MustNewCell(1, 0),
 			wantCount:   1,
 		},
 		{
 			name: "For O turn: when Board has 2 'O' in row should return the empty in this line cell and 1",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.XValue, board.XValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.OValue, board.OValue, board.EmptyValue},
-			},
+			}),
 			value:       board.OValue,
 			wantWinCell: board.MustNewCell(2, 2),
 			wantCount:   1,
 		},
 		{
 			name: "For O turn: when Board has 2 'O' in diagonal should return the empty in this line cell and 1",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.XValue, board.XValue},
 				{board.EmptyValue, board.OValue, board.XValue},
 				{board.XValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			value:       board.OValue,
 			wantWinCell: board.MustNewCell(2, 2),
 			wantCount:   1,
 		},
 		{
 			name: "For O turn: when Board has 2 'O' in 2 lines should return the empty cell in the any found line with 2 'O' and 2",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.XValue, board.XValue},
 				{board.EmptyValue, board.OValue, board.XValue},
 				{board.OValue, board.XValue, board.EmptyValue},
-			},
+			}),
 			value:       board.OValue,
 			wantWinCell: board.MustNewCell(2, 2),
 			wantCount:   2,
 		},
 		{
 			name: "For X turn: when Board has 2 'X' in 2 lines should return the empty cell in the any found line with 2 'X' and 2",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.OValue, board.XValue, board.EmptyValue},
 				{board.XValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			value:       board.XValue,
 			wantWinCell: board.MustNewCell(0, 2),
 			wantCount:   2,
@@ -125,3 +125,47 @@ func TestFindWinSituationFor(t *testing.T) {
 		})
 	}
 }
+
+func TestFindWinCellsFor(t *testing.T) {
+	tests := []struct {
+		name  string
+		b     board.Board
+		value board.CellValue
+		want  []board.Cell
+	}{
+		{
+			name: "when Board don't have win situations should return nil",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.EmptyValue, board.OValue, board.OValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			value: board.XValue,
+		},
+		{
+			name: "when Board has 2 win situations in different cells should return both cells",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.OValue, board.XValue, board.XValue},
+				{board.EmptyValue, board.OValue, board.XValue},
+				{board.OValue, board.XValue, board.EmptyValue},
+			}),
+			value: board.OValue,
+			want:  []board.Cell{board.MustNewCell(1, 0), board.MustNewCell(2, 2)},
+		},
+		{
+			name: "when Board has 2 win situations in the same cell should return the cell once",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.XValue, board.EmptyValue},
+				{board.OValue, board.OValue, board.XValue},
+				{board.EmptyValue, board.OValue, board.XValue},
+			}),
+			value: board.XValue,
+			want:  []board.Cell{board.MustNewCell(0, 2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FindWinCellsFor(tt.b, tt.value), fmt.Sprintf("\nboardhelper:\n%v", tt.b))
+		})
+	}
+}
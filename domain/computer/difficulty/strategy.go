@@ -0,0 +1,234 @@
+// Package difficulty weakens any computer strategy by mixing random and sub-optimal moves into its choices.
+package difficulty
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/minimax"
+	"tictactoe/domain/rules"
+)
+
+// ErrInvalidLevel is returned when a level is unknown.
+var ErrInvalidLevel = errors.New("invalid difficulty level, level must be one of: Easy, Medium, Hard, Perfect")
+
+// Level is the difficulty level.
+type Level int
+
+const (
+	// Easy makes a lot of mistakes.
+	Easy Level = iota + 1
+	// Medium makes mistakes from time to time.
+	Medium
+	// Hard rarely makes mistakes.
+	Hard
+	// Perfect always plays the strategy choice.
+	Perfect
+)
+
+// Levels are all difficulty levels from the easiest one.
+var Levels = []Level{Easy, Medium, Hard, Perfect}
+
+var levelNames = map[Level]string{
+	Easy:    "Easy",
+	Medium:  "Medium",
+	Hard:    "Hard",
+	Perfect: "Perfect",
+}
+
+// String returns the string representation of the Level.
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel returns the level by its case-insensitive string representation.
+func ParseLevel(s string) (Level, error) {
+	for _, l := range Levels {
+		if strings.EqualFold(l.String(), s) {
+			return l, nil
+		}
+	}
+	return 0, ErrInvalidLevel
+}
+
+// Rates are the probabilities of mistakes, from 0 to 1.
+type Rates struct {
+	// Random is the probability to play a random empty cell.
+	Random float64
+	// SubOptimal is the probability to play the second-best move: a move with the best outcome
+	// of the moves whose outcome is worse than the one of the strategy choice, e.g. a draw instead of a win.
+	// The moves are ranked by strategies that score them, e.g. minimax. The other strategies don't rank
+	// their moves, so they play a random legal move other than their choice instead.
+	SubOptimal float64
+}
+
+var levelRates = map[Level]Rates{
+	Easy:    {Random: 0.5, SubOptimal: 0.25},
+	Medium:  {Random: 0.25, SubOptimal: 0.1},
+	Hard:    {Random: 0.1},
+	Perfect: {},
+}
+
+// RatesOf returns the mistake rates of the level.
+func RatesOf(l Level) Rates {
+	return levelRates[l]
+}
+
+// Strategy wraps a computer strategy and makes mistakes at the configured rates.
+// Strategy is not safe for concurrent use.
+type Strategy struct {
+	strategy computer.Strategy
+	level    Level
+	rates    Rates
+	rnd      *rand.Rand
+}
+
+// Option configures the Strategy.
+type Option func(*Strategy)
+
+// WithRates overrides the mistake rates of the level.
+func WithRates(rates Rates) Option {
+	return func(s *Strategy) {
+		s.rates = rates
+	}
+}
+
+// WithSeed makes the random choices reproducible.
+func WithSeed(seed int64) Option {
+	return func(s *Strategy) {
+		s.rnd = rand.New(rand.NewSource(seed))
+	}
+}
+
+// NewStrategy returns a new Strategy that plays the strategy at the level.
+func NewStrategy(strategy computer.Strategy, level Level, opts ...Option) *Strategy {
+	if strategy == nil {
+		panic("strategy is nil")
+	}
+	s := &Strategy{
+		strategy: strategy,
+		level:    level,
+		rates:    RatesOf(level),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.rnd == nil {
+		s.rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return s
+}
+
+// String returns the string representation of the Strategy: the wrapped strategy and the level.
+func (s *Strategy) String() string {
+	return fmt.Sprintf("%s/%s", s.strategy.String(), s.level)
+}
+
+// Level returns the difficulty level.
+func (s *Strategy) Level() Level {
+	return s.level
+}
+
+// FindBestCellForNextTurn finds the cell for the next turn,
+// it is either the wrapped strategy choice or a mistake.
+func (s *Strategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return s.FindBestMove(rules.For(b.Variant()), b).Cell
+}
+
+// FindBestMoveBefore is like FindBestMove but the wrapped strategy searches by the deadline if it supports one.
+func (s *Strategy) FindBestMoveBefore(r rules.Rules, b board.Board, deadline time.Time) rules.Move {
+	return s.chooseMove(r, b, func() rules.Move {
+		return computer.FindBestMoveBefore(s.strategy, r, b, deadline)
+	})
+}
+
+// FindBestMove finds the move for the next turn by the rules,
+// it is either the wrapped strategy choice or a random legal move.
+func (s *Strategy) FindBestMove(r rules.Rules, b board.Board) rules.Move {
+	return s.chooseMove(r, b, func() rules.Move {
+		return computer.FindBestMove(s.strategy, r, b)
+	})
+}
+
+// chooseMove returns either the best move or a mistake at the rates of the strategy,
+// the best move is searched only when it is played or avoided.
+// When the game is over there are no mistakes to make, the choice is left to the wrapped strategy.
+func (s *Strategy) chooseMove(r rules.Rules, b board.Board, bestMove func() rules.Move) rules.Move {
+	moves := r.LegalMoves(b)
+	if len(moves) == 0 {
+		return bestMove()
+	}
+	p := s.rnd.Float64()
+	if p < s.rates.Random {
+		return moves[s.rnd.Intn(len(moves))]
+	}
+	best := bestMove()
+	if p < s.rates.Random+s.rates.SubOptimal {
+		return s.secondBest(b, moves, best)
+	}
+	return best
+}
+
+// scoringStrategy is implemented by strategies that score every legal move, e.g. minimax.
+type scoringStrategy interface {
+	ScoreCells(b board.Board) []minimax.CellScore
+}
+
+// secondBest returns a random move of the best outcome worse than the outcome of the best move,
+// it returns the best move if no move is worse. Moves of strategies that don't score them
+// are not ranked, so it returns a random move other than the best one.
+func (s *Strategy) secondBest(b board.Board, moves []rules.Move, best rules.Move) rules.Move {
+	sc, ok := s.strategy.(scoringStrategy)
+	if !ok {
+		others := make([]rules.Move, 0, len(moves))
+		for _, m := range moves {
+			if m != best {
+				others = append(others, m)
+			}
+		}
+		if len(others) == 0 {
+			return best
+		}
+		return others[s.rnd.Intn(len(others))]
+	}
+	scores := sc.ScoreCells(b)
+	bestOutcome := math.MinInt
+	for _, cs := range scores {
+		if cs.Cell == best.Cell && cs.Mark == best.Mark {
+			bestOutcome = outcome(cs.Score)
+		}
+	}
+	tier := math.MinInt
+	var candidates []rules.Move
+	for _, cs := range scores {
+		o := outcome(cs.Score)
+		if o >= bestOutcome || o < tier {
+			continue
+		}
+		if o > tier {
+			tier, candidates = o, candidates[:0]
+		}
+		candidates = append(candidates, rules.Move{Cell: cs.Cell, Mark: cs.Mark})
+	}
+	if len(candidates) == 0 {
+		return best
+	}
+	return candidates[s.rnd.Intn(len(candidates))]
+}
+
+// outcome returns the outcome of the score: 1 for a win, 0 for a draw and -1 for a loss.
+func outcome(score int) int {
+	switch {
+	case score > 0:
+		return 1
+	case score < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,189 @@
+package difficulty
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/mcts"
+	"tictactoe/domain/computer/strategies/minimax"
+	"tictactoe/domain/rules"
+	"time"
+)
+
+// firstEmptyCellStrategy plays the first empty cell.
+type firstEmptyCellStrategy struct{}
+
+func (firstEmptyCellStrategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return *b.FindFirstEmptyCell()
+}
+
+func (firstEmptyCellStrategy) String() string {
+	return "FirstEmptyCell"
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, l := range Levels {
+		got, err := ParseLevel(l.String())
+		require.NoError(t, err)
+		require.Equal(t, l, got)
+	}
+	got, err := ParseLevel("medium")
+	require.NoError(t, err)
+	require.Equal(t, Medium, got)
+	_, err = ParseLevel("Impossible")
+	require.Equal(t, ErrInvalidLevel, err)
+}
+
+func TestStrategy_String(t *testing.T) {
+	require.Equal(t, "Minimax/Easy", NewStrategy(minimax.NewStrategy(), Easy).String())
+}
+
+func TestStrategy_FindBestCellForNextTurn(t *testing.T) {
+	b := board.MustNewFromRows([][]board.CellValue{
+		{board.XValue, board.EmptyValue, board.EmptyValue},
+		{board.EmptyValue, board.OValue, board.EmptyValue},
+		{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+	})
+	best := firstEmptyCellStrategy{}.FindBestCellForNextTurn(b)
+	t.Run("when level is perfect should always play the strategy choice", func(t *testing.T) {
+		s := NewStrategy(firstEmptyCellStrategy{}, Perfect, WithSeed(1))
+		for i := 0; i < 100; i++ {
+			require.Equal(t, best, s.FindBestCellForNextTurn(b))
+		}
+	})
+	t.Run("when sub-optimal rate is 1 should never play the strategy choice", func(t *testing.T) {
+		s := NewStrategy(firstEmptyCellStrategy{}, Easy, WithSeed(1), WithRates(Rates{SubOptimal: 1}))
+		for i := 0; i < 100; i++ {
+			got := s.FindBestCellForNextTurn(b)
+			require.NotEqual(t, best, got)
+			require.True(t, b.IsEmptyCell(got))
+		}
+	})
+	t.Run("when random rate is 1 should play every empty cell", func(t *testing.T) {
+		s := NewStrategy(firstEmptyCellStrategy{}, Easy, WithSeed(1), WithRates(Rates{Random: 1}))
+		played := map[board.Cell]bool{}
+		for i := 0; i < 200; i++ {
+			got := s.FindBestCellForNextTurn(b)
+			require.True(t, b.IsEmptyCell(got))
+			played[got] = true
+		}
+		require.Len(t, played, 7)
+	})
+	t.Run("when only one cell is empty should play it", func(t *testing.T) {
+		last := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.OValue, board.XValue},
+			{board.XValue, board.OValue, board.OValue},
+			{board.OValue, board.XValue, board.EmptyValue},
+		})
+		s := NewStrategy(firstEmptyCellStrategy{}, Easy, WithSeed(1), WithRates(Rates{SubOptimal: 1}))
+		require.Equal(t, board.MustNewCell(2, 2), s.FindBestCellForNextTurn(last))
+	})
+	t.Run("when seed is the same should return the same cells", func(t *testing.T) {
+		first := NewStrategy(firstEmptyCellStrategy{}, Medium, WithSeed(5))
+		second := NewStrategy(firstEmptyCellStrategy{}, Medium, WithSeed(5))
+		for i := 0; i < 50; i++ {
+			require.Equal(t, first.FindBestCellForNextTurn(b), second.FindBestCellForNextTurn(b))
+		}
+	})
+	t.Run("when the game is over should leave the choice to the strategy", func(t *testing.T) {
+		won := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, board.XValue},
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		full := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.OValue, board.XValue},
+			{board.XValue, board.OValue, board.OValue},
+			{board.OValue, board.XValue, board.XValue},
+		})
+		for _, rates := range []Rates{{Random: 1}, {SubOptimal: 1}} {
+			s := NewStrategy(minimax.NewStrategy(), Easy, WithSeed(1), WithRates(rates))
+			require.Equal(t, minimax.NewStrategy().FindBestCellForNextTurn(won), s.FindBestCellForNextTurn(won))
+			require.Equal(t, minimax.NewStrategy().FindBestCellForNextTurn(full), s.FindBestCellForNextTurn(full))
+		}
+	})
+}
+
+func TestStrategy_SubOptimal(t *testing.T) {
+	tests := []struct {
+		name string
+		b    board.Board
+		want []board.Cell
+	}{
+		{
+			name: "when the strategy can win should play a move that draws",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.OValue},
+			}),
+			want: []board.Cell{board.MustNewCell(1, 1), board.MustNewCell(1, 2), board.MustNewCell(2, 1)},
+		},
+		{
+			name: "when the strategy can draw should play a move that loses",
+			b:    board.Board{}.MustSetCellValue(board.MustNewCell(1, 1)),
+			want: []board.Cell{board.MustNewCell(0, 1), board.MustNewCell(1, 0), board.MustNewCell(1, 2), board.MustNewCell(2, 1)},
+		},
+		{
+			name: "when no move is worse should play the strategy choice",
+			b:    board.Board{},
+			want: []board.Cell{minimax.NewStrategy().FindBestCellForNextTurn(board.Board{})},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStrategy(minimax.NewStrategy(), Easy, WithSeed(1), WithRates(Rates{SubOptimal: 1}))
+			played := map[board.Cell]bool{}
+			for i := 0; i < 50; i++ {
+				got := s.FindBestCellForNextTurn(tt.b)
+				require.Contains(t, tt.want, got)
+				played[got] = true
+			}
+			require.Len(t, played, len(tt.want))
+		})
+	}
+}
+
+func TestStrategy_FindBestMove(t *testing.T) {
+	b := board.Board{}.WithVariant(board.Wild)
+	t.Run("when random rate is 1 should play both marks", func(t *testing.T) {
+		s := NewStrategy(firstEmptyCellStrategy{}, Easy, WithSeed(1), WithRates(Rates{Random: 1}))
+		marks := map[board.CellValue]bool{}
+		for i := 0; i < 50; i++ {
+			marks[s.FindBestMove(rules.Wild{}, b).Mark] = true
+		}
+		require.Len(t, marks, 2)
+	})
+	t.Run("when level is perfect should play the strategy choice with the mark of the side to move", func(t *testing.T) {
+		s := NewStrategy(firstEmptyCellStrategy{}, Perfect, WithSeed(1))
+		require.Equal(t, rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.XValue}, s.FindBestMove(rules.Wild{}, b))
+	})
+}
+
+func TestStrategy_FindBestMoveBefore(t *testing.T) {
+	t.Run("when the wrapped strategy supports deadlines should stop the search at the deadline", func(t *testing.T) {
+		b := board.MustNew(15, 5)
+		s := NewStrategy(mcts.NewStrategy(mcts.WithSeed(1), mcts.WithTimeBudget(time.Hour)), Hard, WithSeed(1))
+		p := computer.New(s)
+		start := time.Now()
+		got := p.WithDeadline(start.Add(50 * time.Millisecond)).GetNextCell(b)
+		require.Less(t, time.Since(start), time.Second)
+		require.True(t, b.IsEmptyCell(got))
+	})
+	t.Run("when the wrapped strategy doesn't support deadlines should play its choice", func(t *testing.T) {
+		s := NewStrategy(firstEmptyCellStrategy{}, Perfect)
+		got := s.FindBestMoveBefore(rules.Classic{}, board.Board{}, time.Now().Add(time.Second))
+		require.Equal(t, rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.XValue}, got)
+	})
+	t.Run("when the game is timed and wild should place the mark the wrapped strategy chooses", func(t *testing.T) {
+		b := board.Board{}.WithVariant(board.Wild)
+		b = rules.MustApply(rules.Wild{}, b, rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.OValue})
+		b = rules.MustApply(rules.Wild{}, b, rules.Move{Cell: board.MustNewCell(0, 1), Mark: board.OValue})
+		for _, level := range Levels {
+			s := NewStrategy(minimax.NewStrategy(), level, WithRates(Rates{}))
+			got := computer.New(s).WithDeadline(time.Now().Add(time.Second)).GetNextMove(b)
+			require.Equal(t, rules.Move{Cell: board.MustNewCell(0, 2), Mark: board.OValue}, got, level.String())
+		}
+	})
+}
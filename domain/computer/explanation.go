@@ -0,0 +1,20 @@
+package computer
+
+import "tictactoe/domain/board"
+
+// Explanation describes why a strategy has chosen the cell.
+type Explanation struct {
+	Cell board.Cell
+	// Rule is the name of the strategy rule that has chosen the cell, e.g. "Block".
+	Rule string
+	// Considered are the candidate cells the rule has chosen the cell from.
+	Considered []board.Cell
+}
+
+// ExplainingStrategy is the optional interface of strategies that explain their choices.
+type ExplainingStrategy interface {
+	Strategy
+	// ExplainBestCellForNextTurn finds the best cell for the next turn like FindBestCellForNextTurn
+	// and explains the choice.
+	ExplainBestCellForNextTurn(b board.Board) Explanation
+}
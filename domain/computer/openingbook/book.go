@@ -0,0 +1,239 @@
+// Package openingbook plays the first moves of a game from a book of weighted candidate moves
+// and delegates the rest of the game to any computer strategy.
+//
+// A book is a text file. Blank lines and lines starting with # are ignored.
+// The first line describes the board in the fields of the record text notation:
+//
+//	size=3 win=3
+//
+// The rows and the variant fields are optional. Every other line is a position followed by its candidate moves:
+// the moves from the empty board in the cell notation separated by commas, a colon,
+// and the candidate cells with their weights separated by spaces, e.g.:
+//
+//	: b2=4 a1=3 b1=1
+//	b2,a1: c3=1 c1=1
+//
+// Positions are stored in their canonical form, so a line covers every rotation and reflection of the position
+// and the candidates of equivalent positions add up.
+package openingbook
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/record"
+)
+
+var (
+	// ErrInvalidBook is returned when a book can't be parsed.
+	ErrInvalidBook = errors.New("invalid opening book")
+	// ErrUnsupportedVariant is returned when a book is written for the wild variant, its moves carry marks.
+	ErrUnsupportedVariant = errors.New("unsupported variant, opening books don't support the wild variant")
+)
+
+// Candidate is a candidate move of a position.
+type Candidate struct {
+	Cell board.Cell
+	// Weight is the relative probability to play the candidate.
+	Weight int
+}
+
+// Book maps positions of a board to the weighted candidate moves.
+type Book struct {
+	// board is the empty board the book is written for.
+	board board.Board
+	// positions are indexed by the hash of the canonical position, the candidates are canonical cells.
+	positions map[board.Hash][]Candidate
+}
+
+// Board returns the empty board the book is written for.
+func (bk *Book) Board() board.Board {
+	return bk.board
+}
+
+// Len returns the count of canonical positions in the book.
+func (bk *Book) Len() int {
+	return len(bk.positions)
+}
+
+// Candidates returns the candidate moves of the position in its coordinates in row-major order,
+// it returns false if the position is not in the book.
+func (bk *Book) Candidates(b board.Board) ([]Candidate, bool) {
+	canonical, s := b.Canonical()
+	candidates, ok := bk.positions[canonical.Hash()]
+	if !ok {
+		return nil, false
+	}
+	result := make([]Candidate, len(candidates))
+	for i, c := range candidates {
+		result[i] = Candidate{Cell: canonical.TransformCell(s.Inverse(), c.Cell), Weight: c.Weight}
+	}
+	sortCandidates(result)
+	return result, true
+}
+
+// Load reads the book from r.
+func Load(r io.Reader) (*Book, error) {
+	var bk *Book
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var err error
+		if bk == nil {
+			bk, err = parseHeader(line)
+		} else {
+			err = bk.parsePosition(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if bk == nil {
+		return nil, fmt.Errorf("%w: the board line is missing", ErrInvalidBook)
+	}
+	return bk, nil
+}
+
+// parseHeader parses the board line and returns the empty book of the board.
+func parseHeader(line string) (*Book, error) {
+	fields := map[string]string{}
+	for _, f := range strings.Fields(line) {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: field %q is not key=value", ErrInvalidBook, f)
+		}
+		fields[key] = value
+	}
+	size, err := strconv.Atoi(fields["size"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid size %q", ErrInvalidBook, fields["size"])
+	}
+	rows := size
+	if s, ok := fields["rows"]; ok {
+		if rows, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("%w: invalid rows %q", ErrInvalidBook, s)
+		}
+	}
+	winLength := size
+	if s, ok := fields["win"]; ok {
+		if winLength, err = strconv.Atoi(s); err != nil {
+			return nil, fmt.Errorf("%w: invalid win length %q", ErrInvalidBook, s)
+		}
+	}
+	b, err := board.NewRectangular(rows, size, winLength)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := fields["variant"]; ok {
+		v, err := board.ParseVariant(s)
+		if err != nil {
+			return nil, err
+		}
+		if v == board.Wild {
+			return nil, ErrUnsupportedVariant
+		}
+		b = b.WithVariant(v)
+	}
+	return &Book{board: b, positions: map[board.Hash][]Candidate{}}, nil
+}
+
+// parsePosition parses the position line and adds its candidates to the book.
+func (bk *Book) parsePosition(line string) error {
+	moves, candidates, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("%w: the colon after the moves is missing", ErrInvalidBook)
+	}
+	b := bk.board
+	if moves = strings.TrimSpace(moves); moves != "" {
+		for _, m := range strings.Split(moves, ",") {
+			cell, err := record.ParseCell(strings.TrimSpace(m))
+			if err != nil {
+				return err
+			}
+			if b, err = b.SetCellValue(cell); err != nil {
+				return fmt.Errorf("move %s: %w", m, err)
+			}
+		}
+	}
+	if b.IsCompleted() {
+		return board.ErrGameIsOver
+	}
+	var parsed []Candidate
+	for _, f := range strings.Fields(candidates) {
+		c, err := parseCandidate(b, f)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, c)
+	}
+	if len(parsed) == 0 {
+		return fmt.Errorf("%w: the position has no candidates", ErrInvalidBook)
+	}
+	bk.add(b, parsed)
+	return nil
+}
+
+// parseCandidate parses the candidate of the position written as the cell and the weight, e.g. "b2=4".
+func parseCandidate(b board.Board, s string) (Candidate, error) {
+	cellNotation, weightNotation, ok := strings.Cut(s, "=")
+	if !ok {
+		return Candidate{}, fmt.Errorf("%w: candidate %q is not cell=weight", ErrInvalidBook, s)
+	}
+	cell, err := record.ParseCell(cellNotation)
+	if err != nil {
+		return Candidate{}, err
+	}
+	if _, err := b.SetCellValue(cell); err != nil {
+		return Candidate{}, fmt.Errorf("candidate %s: %w", cellNotation, err)
+	}
+	weight, err := strconv.Atoi(weightNotation)
+	if err != nil || weight <= 0 {
+		return Candidate{}, fmt.Errorf("%w: candidate %s weight must be a positive integer", ErrInvalidBook, cellNotation)
+	}
+	return Candidate{Cell: cell, Weight: weight}, nil
+}
+
+// add adds the candidates of the position to the candidates of its canonical form.
+func (bk *Book) add(b board.Board, candidates []Candidate) {
+	canonical, s := b.Canonical()
+	key := canonical.Hash()
+	existing := bk.positions[key]
+	for _, c := range candidates {
+		cell := b.TransformCell(s, c.Cell)
+		found := false
+		for i := range existing {
+			if existing[i].Cell == cell {
+				existing[i].Weight += c.Weight
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, Candidate{Cell: cell, Weight: c.Weight})
+		}
+	}
+	bk.positions[key] = existing
+}
+
+// sortCandidates sorts the candidates by cells in row-major order.
+func sortCandidates(candidates []Candidate) {
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i].Cell, candidates[j].Cell
+		if a.RowNumber != b.RowNumber {
+			return a.RowNumber < b.RowNumber
+		}
+		return a.ColumnNumber < b.ColumnNumber
+	})
+}
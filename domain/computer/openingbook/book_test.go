@@ -0,0 +1,99 @@
+package openingbook
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/analysis"
+	"tictactoe/domain/board"
+)
+
+func TestLoad(t *testing.T) {
+	bk, err := Load(strings.NewReader(`
+# A small book.
+size=3 win=3
+
+: a1=3 b2=1
+b2: c3=1
+b2,a1: c3=2
+b2,c1: a3=1
+`))
+	require.NoError(t, err)
+	require.Equal(t, board.Board{}, bk.Board())
+	require.Equal(t, 3, bk.Len())
+	t.Run("should return the candidates of the position", func(t *testing.T) {
+		got, ok := bk.Candidates(board.Board{})
+		require.True(t, ok)
+		require.Equal(t, []Candidate{{Cell: board.MustNewCell(0, 0), Weight: 3}, {Cell: board.MustNewCell(1, 1), Weight: 1}}, got)
+	})
+	t.Run("when the position is rotated should return the rotated candidates", func(t *testing.T) {
+		b := board.Board{}.MustSetCellValue(board.MustNewCell(1, 1)).MustSetCellValue(board.MustNewCell(2, 2))
+		got, ok := bk.Candidates(b)
+		require.True(t, ok)
+		require.Equal(t, []Candidate{{Cell: board.MustNewCell(0, 0), Weight: 3}}, got)
+	})
+	t.Run("when positions are equivalent should add up the candidates", func(t *testing.T) {
+		b := board.Board{}.MustSetCellValue(board.MustNewCell(1, 1)).MustSetCellValue(board.MustNewCell(0, 0))
+		got, ok := bk.Candidates(b)
+		require.True(t, ok)
+		require.Equal(t, []Candidate{{Cell: board.MustNewCell(2, 2), Weight: 3}}, got)
+	})
+	t.Run("when the position is not in the book should return false", func(t *testing.T) {
+		_, ok := bk.Candidates(board.Board{}.MustSetCellValue(board.MustNewCell(0, 1)))
+		require.False(t, ok)
+		_, ok = bk.Candidates(board.Board{}.WithVariant(board.Misere))
+		require.False(t, ok)
+	})
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		book    string
+		wantErr error
+	}{
+		{name: "when the board line is missing should return error", book: "# empty\n", wantErr: ErrInvalidBook},
+		{name: "when the size is invalid should return error", book: "size=x\n", wantErr: ErrInvalidBook},
+		{name: "when the board is too big should return error", book: "size=16\n", wantErr: board.ErrInvalidSize},
+		{name: "when the variant is wild should return error", book: "size=3 variant=wild\n", wantErr: ErrUnsupportedVariant},
+		{name: "when the colon is missing should return error", book: "size=3\nb2 a1=1\n", wantErr: ErrInvalidBook},
+		{name: "when a move is repeated should return error", book: "size=3\nb2,b2: a1=1\n", wantErr: board.ErrCellIsNotEmpty},
+		{name: "when a candidate is not empty should return error", book: "size=3\nb2: b2=1\n", wantErr: board.ErrCellIsNotEmpty},
+		{name: "when a weight is not positive should return error", book: "size=3\n: b2=0\n", wantErr: ErrInvalidBook},
+		{name: "when the position has no candidates should return error", book: "size=3\nb2:\n", wantErr: ErrInvalidBook},
+		{name: "when the game is over should return error", book: "size=3\na1,b1,a2,b2,a3: c3=1\n", wantErr: board.ErrGameIsOver},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(tt.book))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestClassic(t *testing.T) {
+	bk := Classic()
+	require.Equal(t, board.Board{}, bk.Board())
+	t.Run("should keep the outcome of every position of the book", func(t *testing.T) {
+		var walk func(b board.Board)
+		walk = func(b board.Board) {
+			if candidates, ok := bk.Candidates(b); ok {
+				a, err := analysis.Analyze(b)
+				require.NoError(t, err)
+				for _, c := range candidates {
+					m, ok := a.Move(c.Cell)
+					require.True(t, ok)
+					require.Equal(t, a.Outcome(), m.Outcome, "candidate %v of\n%v", c.Cell, b)
+				}
+			}
+			if b.FullCellsCount() < 3 {
+				for _, cell := range b.EmptyCells() {
+					walk(b.MustSetCellValue(cell))
+				}
+			}
+		}
+		walk(board.Board{})
+	})
+}
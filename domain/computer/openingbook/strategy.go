@@ -0,0 +1,171 @@
+package openingbook
+
+import (
+	"bytes"
+	_ "embed"
+	"math/rand"
+	"sync"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/minimax"
+	"tictactoe/domain/rules"
+)
+
+// RuleOpeningBook is the rule of the moves played from the book.
+const RuleOpeningBook = "Opening book"
+
+//go:embed classic.book
+var classicBook []byte
+
+// classic is the embedded book of the classic board, it is parsed on the first use.
+var classic = struct {
+	once sync.Once
+	book *Book
+}{}
+
+// Classic returns the embedded book of the classic 3x3 board.
+func Classic() *Book {
+	classic.once.Do(func() {
+		bk, err := Load(bytes.NewReader(classicBook))
+		if err != nil {
+			panic(err)
+		}
+		classic.book = bk
+	})
+	return classic.book
+}
+
+// Strategy wraps a computer strategy and plays the positions of the book by the weights of their candidates,
+// the candidate is played at random in any of its equivalent cells.
+// Strategy is not safe for concurrent use.
+type Strategy struct {
+	book     *Book
+	strategy computer.Strategy
+	rnd      *rand.Rand
+}
+
+// Option configures the Strategy.
+type Option func(*Strategy)
+
+// WithSeed makes the random choices reproducible.
+func WithSeed(seed int64) Option {
+	return func(s *Strategy) {
+		s.rnd = rand.New(rand.NewSource(seed))
+	}
+}
+
+// NewStrategy returns a new Strategy that consults the book before delegating to the strategy.
+func NewStrategy(book *Book, strategy computer.Strategy, opts ...Option) *Strategy {
+	if book == nil {
+		panic("book is nil")
+	}
+	if strategy == nil {
+		panic("strategy is nil")
+	}
+	s := &Strategy{
+		book:     book,
+		strategy: strategy,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.rnd == nil {
+		s.rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return s
+}
+
+func init() {
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:           "openingbook",
+		Name:         "Opening Book",
+		Aliases:      []string{"Minimax+Book"},
+		Description:  "plays varied sound openings from the book, then searches like minimax",
+		Variants:     []board.Variant{board.Classic},
+		MaxBoardSize: 4,
+		New: func(opts computer.StrategyOptions) computer.Strategy {
+			return NewStrategy(Classic(), minimax.NewStrategy(), WithSeed(opts.Seed))
+		},
+	})
+}
+
+// String returns the string representation of the Strategy: the wrapped strategy with the book.
+func (s *Strategy) String() string {
+	return s.strategy.String() + "+Book"
+}
+
+// FindBestCellForNextTurn finds the cell for the next turn,
+// it is a candidate of the book or the wrapped strategy choice.
+func (s *Strategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return s.ExplainBestCellForNextTurn(b).Cell
+}
+
+// FindBestMoveBefore finds the move for the next turn by the rules and the deadline,
+// it is a candidate of the book or the wrapped strategy choice searched by the deadline if it supports one.
+func (s *Strategy) FindBestMoveBefore(r rules.Rules, b board.Board, deadline time.Time) rules.Move {
+	if candidates, ok := s.book.Candidates(b); ok {
+		return rules.MoveOf(r, b, s.choose(b, candidates))
+	}
+	return computer.FindBestMoveBefore(s.strategy, r, b, deadline)
+}
+
+// ExplainBestCellForNextTurn finds the cell for the next turn and explains the choice.
+// The moves of the wrapped strategy are explained by it if it explains its choices,
+// otherwise by the rule named after it.
+func (s *Strategy) ExplainBestCellForNextTurn(b board.Board) computer.Explanation {
+	if candidates, ok := s.book.Candidates(b); ok {
+		considered := make([]board.Cell, len(candidates))
+		for i, c := range candidates {
+			considered[i] = c.Cell
+		}
+		return computer.Explanation{Cell: s.choose(b, candidates), Rule: RuleOpeningBook, Considered: considered}
+	}
+	if e, ok := s.strategy.(computer.ExplainingStrategy); ok {
+		return e.ExplainBestCellForNextTurn(b)
+	}
+	cell := s.strategy.FindBestCellForNextTurn(b)
+	return computer.Explanation{Cell: cell, Rule: s.strategy.String(), Considered: []board.Cell{cell}}
+}
+
+// choose chooses a candidate by the weights and returns one of its equivalent cells at random.
+func (s *Strategy) choose(b board.Board, candidates []Candidate) board.Cell {
+	total := 0
+	for _, c := range candidates {
+		total += c.Weight
+	}
+	p := s.rnd.Intn(total)
+	chosen := candidates[len(candidates)-1]
+	for _, c := range candidates {
+		if p < c.Weight {
+			chosen = c
+			break
+		}
+		p -= c.Weight
+	}
+	cells := equivalentCells(b, chosen.Cell)
+	return cells[s.rnd.Intn(len(cells))]
+}
+
+// equivalentCells returns the cells the symmetries that keep the position map the cell to, the cell included.
+func equivalentCells(b board.Board, cell board.Cell) []board.Cell {
+	cells := []board.Cell{cell}
+	for _, sym := range b.Symmetries() {
+		if b.MustTransform(sym) != b {
+			continue
+		}
+		c := b.TransformCell(sym, cell)
+		found := false
+		for _, existing := range cells {
+			if existing == c {
+				found = true
+				break
+			}
+		}
+		if !found {
+			cells = append(cells, c)
+		}
+	}
+	return cells
+}
@@ -0,0 +1,95 @@
+package openingbook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/mcts"
+	"tictactoe/domain/computer/strategies/minimax"
+	"tictactoe/domain/computer/strategies/wiki"
+	"tictactoe/domain/rules"
+)
+
+func TestStrategy_FindBestCellForNextTurn(t *testing.T) {
+	bk, err := Load(strings.NewReader("size=3\n: a1=1 b2=3\nb2: a1=1\n"))
+	require.NoError(t, err)
+	t.Run("should play the candidates by their weights in every equivalent cell", func(t *testing.T) {
+		s := NewStrategy(bk, minimax.NewStrategy(), WithSeed(1))
+		played := map[board.Cell]int{}
+		const games = 4000
+		for i := 0; i < games; i++ {
+			played[s.FindBestCellForNextTurn(board.Board{})]++
+		}
+		require.Len(t, played, 5)
+		require.InDelta(t, 0.75, float64(played[board.MustNewCell(1, 1)])/games, 0.03)
+		for _, corner := range (board.Board{}).Corners() {
+			require.InDelta(t, 0.0625, float64(played[corner])/games, 0.02)
+		}
+	})
+	t.Run("when the position is not in the book should play the strategy choice", func(t *testing.T) {
+		b := board.Board{}.MustSetCellValue(board.MustNewCell(0, 1))
+		s := NewStrategy(bk, minimax.NewStrategy(), WithSeed(1))
+		require.Equal(t, minimax.NewStrategy().FindBestCellForNextTurn(b), s.FindBestCellForNextTurn(b))
+	})
+	t.Run("when seed is the same should return the same cells", func(t *testing.T) {
+		first := NewStrategy(bk, minimax.NewStrategy(), WithSeed(5))
+		second := NewStrategy(bk, minimax.NewStrategy(), WithSeed(5))
+		for i := 0; i < 20; i++ {
+			require.Equal(t, first.FindBestCellForNextTurn(board.Board{}), second.FindBestCellForNextTurn(board.Board{}))
+		}
+	})
+}
+
+func TestStrategy_FindBestMoveBefore(t *testing.T) {
+	bk, err := Load(strings.NewReader("size=3\n: b2=1\n"))
+	require.NoError(t, err)
+	s := NewStrategy(bk, mcts.NewStrategy(mcts.WithSeed(1), mcts.WithTimeBudget(time.Hour)), WithSeed(1))
+	t.Run("when the position is in the book should play the candidate", func(t *testing.T) {
+		got := s.FindBestMoveBefore(rules.Classic{}, board.Board{}, time.Now().Add(time.Second))
+		require.Equal(t, rules.Move{Cell: board.MustNewCell(1, 1), Mark: board.XValue}, got)
+	})
+	t.Run("when the position is not in the book should stop the search at the deadline", func(t *testing.T) {
+		b := board.Board{}.MustSetCellValue(board.MustNewCell(0, 1))
+		start := time.Now()
+		got := computer.New(s).WithDeadline(start.Add(50 * time.Millisecond)).GetNextCell(b)
+		require.Less(t, time.Since(start), time.Second)
+		require.True(t, b.IsEmptyCell(got))
+	})
+}
+
+func TestStrategy_ExplainBestCellForNextTurn(t *testing.T) {
+	b := board.Board{}.MustSetCellValue(board.MustNewCell(1, 1))
+	t.Run("when the position is in the book should explain the move by the book", func(t *testing.T) {
+		got := NewStrategy(Classic(), minimax.NewStrategy(), WithSeed(1)).ExplainBestCellForNextTurn(b)
+		require.Equal(t, RuleOpeningBook, got.Rule)
+		require.Contains(t, b.Corners(), got.Cell)
+	})
+	t.Run("when the strategy explains its moves should return its explanation", func(t *testing.T) {
+		after := b.MustSetCellValue(board.MustNewCell(0, 1))
+		got := NewStrategy(Classic(), wiki.NewStrategy()).ExplainBestCellForNextTurn(after)
+		require.Equal(t, wiki.NewStrategy().ExplainBestCellForNextTurn(after), got)
+	})
+	t.Run("when the strategy doesn't explain its moves should name it as the rule", func(t *testing.T) {
+		after := b.MustSetCellValue(board.MustNewCell(0, 1))
+		got := NewStrategy(Classic(), minimax.NewStrategy()).ExplainBestCellForNextTurn(after)
+		require.Equal(t, computer.Explanation{
+			Cell:       minimax.NewStrategy().FindBestCellForNextTurn(after),
+			Rule:       "Minimax",
+			Considered: []board.Cell{minimax.NewStrategy().FindBestCellForNextTurn(after)},
+		}, got)
+	})
+}
+
+func TestStrategy_String(t *testing.T) {
+	require.Equal(t, "Minimax+Book", NewStrategy(Classic(), minimax.NewStrategy()).String())
+	t.Run("when the string representation is recorded should find the strategy", func(t *testing.T) {
+		info, err := computer.LookupStrategy("Minimax+Book")
+		require.NoError(t, err)
+		require.Equal(t, "openingbook", info.ID)
+	})
+}
@@ -2,8 +2,10 @@ package computer
 
 import (
 	"tictactoe/domain/board"
+	"tictactoe/domain/rules"
 
 	"fmt"
+	"time"
 )
 
 const (
@@ -16,9 +18,26 @@ type Strategy interface {
 	String() string
 }
 
+// RulesStrategy is implemented by strategies that play by any rules, not only by the ones built into the board.
+type RulesStrategy interface {
+	Strategy
+	FindBestMove(r rules.Rules, b board.Board) rules.Move
+}
+
+// DeadlineStrategy is implemented by strategies whose search can be bounded by time.
+type DeadlineStrategy interface {
+	Strategy
+	// FindBestMoveBefore finds the best move for the next turn by the rules and returns it by the deadline.
+	FindBestMoveBefore(r rules.Rules, b board.Board, deadline time.Time) rules.Move
+}
+
 // Player is the computer player.
 type Player struct {
 	strategy Strategy
+	// rules are the rules the player plays by, nil means the rules of the board variant.
+	rules rules.Rules
+	// deadline is the time the next move must be chosen by, zero means there is no deadline.
+	deadline time.Time
 }
 
 // New creates a new computer player.
@@ -31,6 +50,12 @@ func New(strategy Strategy) Player {
 	}
 }
 
+// WithRules returns the player that plays by the rules instead of the rules of the board variant.
+func (p Player) WithRules(r rules.Rules) Player {
+	p.rules = r
+	return p
+}
+
 // Name returns the computer player name.
 func (p Player) Name() string {
 	return fmt.Sprintf(
@@ -42,7 +67,64 @@ func (p Player) String() string {
 	return p.Name()
 }
 
+// Strategy returns the computer player strategy.
+func (p Player) Strategy() Strategy {
+	return p.strategy
+}
+
 // GetNextCell returns the next turn cell.
 func (p Player) GetNextCell(b board.Board) board.Cell {
-	return p.strategy.FindBestCellForNextTurn(b)
+	return p.GetNextMove(b).Cell
+}
+
+// WithDeadline returns the player that chooses the next move by the deadline, e.g. before its flag falls.
+// Only strategies that implement DeadlineStrategy respect it.
+func (p Player) WithDeadline(deadline time.Time) Player {
+	p.deadline = deadline
+	return p
+}
+
+// GetNextMove returns the next turn move by the rules of the player.
+func (p Player) GetNextMove(b board.Board) rules.Move {
+	if !p.deadline.IsZero() {
+		return FindBestMoveBefore(p.strategy, p.rulesOf(b), b, p.deadline)
+	}
+	return FindBestMove(p.strategy, p.rulesOf(b), b)
+}
+
+// GetNextMoveWithExplanation returns the next turn move and the explanation of the choice,
+// the explanation is nil if the strategy doesn't explain its choices.
+func (p Player) GetNextMoveWithExplanation(b board.Board) (rules.Move, *Explanation) {
+	s, ok := p.strategy.(ExplainingStrategy)
+	if !ok {
+		return p.GetNextMove(b), nil
+	}
+	e := s.ExplainBestCellForNextTurn(b)
+	return rules.MoveOf(p.rulesOf(b), b, e.Cell), &e
+}
+
+// rulesOf returns the rules the player plays by on the board.
+func (p Player) rulesOf(b board.Board) rules.Rules {
+	if p.rules != nil {
+		return p.rules
+	}
+	return rules.For(b.Variant())
+}
+
+// FindBestMove returns the move the strategy chooses by the rules.
+// Strategies that don't implement RulesStrategy choose only the cell, the mark is the one of the side to move.
+func FindBestMove(s Strategy, r rules.Rules, b board.Board) rules.Move {
+	if s, ok := s.(RulesStrategy); ok {
+		return s.FindBestMove(r, b)
+	}
+	return rules.MoveOf(r, b, s.FindBestCellForNextTurn(b))
+}
+
+// FindBestMoveBefore returns the move the strategy chooses by the rules and the deadline.
+// Strategies that don't implement DeadlineStrategy ignore the deadline and choose like FindBestMove.
+func FindBestMoveBefore(s Strategy, r rules.Rules, b board.Board, deadline time.Time) rules.Move {
+	if s, ok := s.(DeadlineStrategy); ok {
+		return s.FindBestMoveBefore(r, b, deadline)
+	}
+	return FindBestMove(s, r, b)
 }
@@ -0,0 +1,67 @@
+package computer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/rules"
+)
+
+// markingStrategy plays the first empty cell with the O mark by any rules.
+type markingStrategy struct {
+	seededStrategy
+}
+
+func (s markingStrategy) FindBestMove(_ rules.Rules, b board.Board) rules.Move {
+	return rules.Move{Cell: *b.FindFirstEmptyCell(), Mark: board.OValue}
+}
+
+func TestPlayer_GetNextMove(t *testing.T) {
+	b := board.Board{}
+	tests := []struct {
+		name     string
+		strategy Strategy
+		want     rules.Move
+	}{
+		{
+			name:     "when the strategy chooses only the cell should play the mark of the side to move",
+			strategy: seededStrategy{},
+			want:     rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.XValue},
+		},
+		{
+			name:     "when the strategy plays by the rules should play its move",
+			strategy: markingStrategy{},
+			want:     rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.OValue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New(tt.strategy).WithRules(rules.Classic{})
+			require.Equal(t, tt.want, p.GetNextMove(b))
+			require.Equal(t, tt.want.Cell, p.GetNextCell(b))
+		})
+	}
+}
+
+// lastCellStrategy plays the last empty cell when the search is bounded by time.
+type lastCellStrategy struct {
+	seededStrategy
+}
+
+func (s lastCellStrategy) FindBestMoveBefore(r rules.Rules, b board.Board, _ time.Time) rules.Move {
+	cells := b.EmptyCells()
+	return rules.MoveOf(r, b, cells[len(cells)-1])
+}
+
+func TestPlayer_WithDeadline(t *testing.T) {
+	p := New(lastCellStrategy{})
+	t.Run("when there is no deadline should search without it", func(t *testing.T) {
+		require.Equal(t, board.MustNewCell(0, 0), p.GetNextCell(board.Board{}))
+	})
+	t.Run("when there is the deadline should pass it to the strategy", func(t *testing.T) {
+		require.Equal(t, board.MustNewCell(2, 2), p.WithDeadline(time.Now().Add(time.Second)).GetNextCell(board.Board{}))
+	})
+}
@@ -0,0 +1,214 @@
+package computer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"tictactoe/domain/board"
+)
+
+var (
+	// ErrUnknownStrategy is returned when no strategy is registered under the name.
+	ErrUnknownStrategy = errors.New("unknown computer strategy")
+	// ErrUnsupportedBoard is returned when a strategy doesn't play the variant or the dimensions of the board.
+	ErrUnsupportedBoard = errors.New("the computer strategy doesn't play the board")
+)
+
+// StrategyOptions are the options passed to strategy constructors.
+// Strategies ignore the options they don't support.
+type StrategyOptions struct {
+	// Seed is used by strategies that make random choices.
+	Seed int64
+}
+
+// StrategyOption configures StrategyOptions.
+type StrategyOption func(*StrategyOptions)
+
+// WithSeed sets the seed of random choices, so strategies play reproducibly.
+func WithSeed(seed int64) StrategyOption {
+	return func(o *StrategyOptions) {
+		o.Seed = seed
+	}
+}
+
+// StrategyConstructor creates a new strategy with the options.
+type StrategyConstructor func(opts StrategyOptions) Strategy
+
+// StrategyInfo describes a registered strategy.
+type StrategyInfo struct {
+	// ID is the stable lower-case identifier used in flags and APIs, e.g. "minimax".
+	ID string
+	// Name is the display name, e.g. "Minimax".
+	Name string
+	// Aliases are the other names the strategy is found by, e.g. the string representation of the strategy
+	// written in records when it matches neither the ID nor the name.
+	Aliases []string
+	// Description is the short description of how the strategy plays.
+	Description string
+	// Variants are the board variants the strategy plays, nil means only the Classic one.
+	Variants []board.Variant
+	// MaxBoardSize is the biggest count of rows and columns of the boards the strategy plays,
+	// bigger boards can't be played or take too long to search. Zero means boards of any size.
+	MaxBoardSize int
+	// New creates a new strategy.
+	New StrategyConstructor
+}
+
+// Supports returns true if the strategy plays the variant.
+func (info StrategyInfo) Supports(v board.Variant) bool {
+	if info.Variants == nil {
+		return v == board.Classic
+	}
+	for _, supported := range info.Variants {
+		if supported == v {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsBoard returns true if the strategy plays the variant and the dimensions of the board.
+func (info StrategyInfo) SupportsBoard(b board.Board) bool {
+	if info.MaxBoardSize > 0 && (b.Rows() > info.MaxBoardSize || b.Columns() > info.MaxBoardSize) {
+		return false
+	}
+	return info.Supports(b.Variant())
+}
+
+var registry = struct {
+	sync.RWMutex
+	strategies map[string]StrategyInfo
+}{strategies: map[string]StrategyInfo{}}
+
+// RegisterStrategy registers the strategy, strategies register themselves in init functions.
+// It panics if the ID is empty or already registered.
+func RegisterStrategy(info StrategyInfo) {
+	if info.ID == "" || info.ID != strings.ToLower(info.ID) {
+		panic("strategy ID must be a non-empty lower-case string")
+	}
+	if info.New == nil {
+		panic("strategy constructor is nil")
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.strategies[info.ID]; ok {
+		panic(fmt.Sprintf("strategy %q is already registered", info.ID))
+	}
+	registry.strategies[info.ID] = info
+}
+
+// Strategies returns the registered strategies ordered by ID.
+func Strategies() []StrategyInfo {
+	registry.RLock()
+	defer registry.RUnlock()
+	strategies := make([]StrategyInfo, 0, len(registry.strategies))
+	for _, info := range registry.strategies {
+		strategies = append(strategies, info)
+	}
+	sort.Slice(strategies, func(i, j int) bool {
+		return strategies[i].ID < strategies[j].ID
+	})
+	return strategies
+}
+
+// StrategiesFor returns the registered strategies that play the variant ordered by ID.
+func StrategiesFor(v board.Variant) []StrategyInfo {
+	var strategies []StrategyInfo
+	for _, info := range Strategies() {
+		if info.Supports(v) {
+			strategies = append(strategies, info)
+		}
+	}
+	return strategies
+}
+
+// StrategyIDs returns the IDs of the registered strategies in order.
+func StrategyIDs() []string {
+	strategies := Strategies()
+	ids := make([]string, len(strategies))
+	for i, info := range strategies {
+		ids[i] = info.ID
+	}
+	return ids
+}
+
+// LookupStrategy returns the registered strategy by its case-insensitive ID, name or alias,
+// so strategies written in records are found without creating them.
+func LookupStrategy(name string) (StrategyInfo, error) {
+	registry.RLock()
+	info, ok := registry.strategies[strings.ToLower(name)]
+	registry.RUnlock()
+	if ok {
+		return info, nil
+	}
+	for _, info := range Strategies() {
+		if info.isNamed(name) {
+			return info, nil
+		}
+	}
+	return StrategyInfo{}, fmt.Errorf("%w %q, available strategies: %s",
+		ErrUnknownStrategy, name, strings.Join(StrategyIDs(), ", "))
+}
+
+// isNamed returns true if the name is the case-insensitive name or alias of the strategy.
+func (info StrategyInfo) isNamed(name string) bool {
+	if strings.EqualFold(info.Name, name) {
+		return true
+	}
+	for _, alias := range info.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewStrategy creates the registered strategy by its name, see LookupStrategy.
+// The seed is based on the current time unless it is set by the options.
+func NewStrategy(name string, opts ...StrategyOption) (Strategy, error) {
+	info, err := LookupStrategy(name)
+	if err != nil {
+		return nil, err
+	}
+	o := StrategyOptions{Seed: time.Now().UnixNano()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return info.New(o), nil
+}
+
+// CheckBoard returns ErrUnsupportedBoard if the registered strategy with the name doesn't play the board
+// and ErrUnknownStrategy if no strategy is registered under the name.
+// The name may be followed by a slash and a modifier of the strategy, e.g. the difficulty level in "minimax/easy".
+func CheckBoard(name string, b board.Board) error {
+	base, _, _ := strings.Cut(name, "/")
+	info, err := LookupStrategy(base)
+	if err != nil {
+		return err
+	}
+	if !info.SupportsBoard(b) {
+		return fmt.Errorf("%w: %s plays %s", ErrUnsupportedBoard, info.ID, info.boardsDescription())
+	}
+	return nil
+}
+
+// boardsDescription describes the boards the strategy plays, e.g. "classic boards up to 3x3".
+func (info StrategyInfo) boardsDescription() string {
+	variants := info.Variants
+	if variants == nil {
+		variants = []board.Variant{board.Classic}
+	}
+	names := make([]string, len(variants))
+	for i, v := range variants {
+		names[i] = v.String()
+	}
+	s := strings.Join(names, ", ") + " boards"
+	if info.MaxBoardSize > 0 {
+		s += fmt.Sprintf(" up to %dx%d", info.MaxBoardSize, info.MaxBoardSize)
+	}
+	return s
+}
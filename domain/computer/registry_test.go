@@ -0,0 +1,155 @@
+package computer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+)
+
+// seededStrategy plays the first empty cell and remembers its seed.
+type seededStrategy struct {
+	seed int64
+}
+
+func (s seededStrategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return *b.FindFirstEmptyCell()
+}
+
+func (s seededStrategy) String() string {
+	return "Seeded Test"
+}
+
+func init() {
+	RegisterStrategy(StrategyInfo{
+		ID:          "seededtest",
+		Name:        "Seeded Test",
+		Description: "plays the first empty cell",
+		Aliases:     []string{"Seeded+Alias"},
+		New: func(opts StrategyOptions) Strategy {
+			return seededStrategy{seed: opts.Seed}
+		},
+	})
+}
+
+func TestRegisterStrategy(t *testing.T) {
+	t.Run("when ID is already registered should panic", func(t *testing.T) {
+		require.Panics(t, func() {
+			RegisterStrategy(StrategyInfo{ID: "seededtest", New: func(StrategyOptions) Strategy { return seededStrategy{} }})
+		})
+	})
+	t.Run("when ID is not lower-case should panic", func(t *testing.T) {
+		require.Panics(t, func() {
+			RegisterStrategy(StrategyInfo{ID: "Test", New: func(StrategyOptions) Strategy { return seededStrategy{} }})
+		})
+	})
+	t.Run("when constructor is nil should panic", func(t *testing.T) {
+		require.Panics(t, func() {
+			RegisterStrategy(StrategyInfo{ID: "nilconstructor"})
+		})
+	})
+}
+
+func TestStrategies(t *testing.T) {
+	require.Contains(t, StrategyIDs(), "seededtest")
+	ids := StrategyIDs()
+	for i := 1; i < len(ids); i++ {
+		require.Less(t, ids[i-1], ids[i])
+	}
+}
+
+func TestNewStrategy(t *testing.T) {
+	t.Run("when name is the ID should create the strategy with the options", func(t *testing.T) {
+		s, err := NewStrategy("SeededTest", WithSeed(42))
+		require.NoError(t, err)
+		require.Equal(t, seededStrategy{seed: 42}, s)
+	})
+	t.Run("when name is the display name should create the strategy", func(t *testing.T) {
+		s, err := NewStrategy("seeded test")
+		require.NoError(t, err)
+		require.IsType(t, seededStrategy{}, s)
+	})
+	t.Run("when name is an alias should create the strategy", func(t *testing.T) {
+		s, err := NewStrategy("Seeded+Alias")
+		require.NoError(t, err)
+		require.IsType(t, seededStrategy{}, s)
+	})
+	t.Run("when name is unknown should return error listing the available IDs", func(t *testing.T) {
+		_, err := NewStrategy("random")
+		require.ErrorIs(t, err, ErrUnknownStrategy)
+		require.Contains(t, err.Error(), `"random"`)
+		require.Contains(t, err.Error(), "available strategies: seededtest")
+	})
+}
+
+func TestLookupStrategy(t *testing.T) {
+	t.Run("when name is not the ID should find the strategy without creating strategies", func(t *testing.T) {
+		RegisterStrategy(StrategyInfo{
+			ID:   "panictest",
+			Name: "Panic Test",
+			New:  func(StrategyOptions) Strategy { panic("strategy must not be created") },
+		})
+		info, err := LookupStrategy("Seeded Test")
+		require.NoError(t, err)
+		require.Equal(t, "seededtest", info.ID)
+	})
+}
+
+func TestStrategiesFor(t *testing.T) {
+	t.Run("when strategy doesn't declare variants should support only the classic one", func(t *testing.T) {
+		info, err := LookupStrategy("seededtest")
+		require.NoError(t, err)
+		require.True(t, info.Supports(board.Classic))
+		require.False(t, info.Supports(board.Misere))
+		ids := func(strategies []StrategyInfo) []string {
+			var ids []string
+			for _, s := range strategies {
+				ids = append(ids, s.ID)
+			}
+			return ids
+		}
+		require.Contains(t, ids(StrategiesFor(board.Classic)), "seededtest")
+		require.NotContains(t, ids(StrategiesFor(board.Misere)), "seededtest")
+	})
+	t.Run("when strategy declares variants should support only them", func(t *testing.T) {
+		info := StrategyInfo{Variants: []board.Variant{board.Misere}}
+		require.True(t, info.Supports(board.Misere))
+		require.False(t, info.Supports(board.Classic))
+	})
+}
+
+func TestCheckBoard(t *testing.T) {
+	RegisterStrategy(StrategyInfo{
+		ID:           "sizedtest",
+		Variants:     []board.Variant{board.Classic, board.Misere},
+		MaxBoardSize: 4,
+		New:          func(StrategyOptions) Strategy { return seededStrategy{} },
+	})
+	tests := []struct {
+		name    string
+		input   string
+		board   board.Board
+		wantErr bool
+	}{
+		{name: "when board fits should return nil", input: "sizedtest", board: board.MustNew(4, 3)},
+		{name: "when name has modifier should check the strategy", input: "sizedtest/easy", board: board.MustNew(5, 4), wantErr: true},
+		{name: "when board is too big should return error", input: "sizedtest", board: board.MustNewRectangular(3, 5, 3), wantErr: true},
+		{name: "when variant is not supported should return error", input: "sizedtest", board: board.Board{}.WithVariant(board.Gravity), wantErr: true},
+		{name: "when size is unlimited should return nil", input: "seededtest", board: board.MustNew(board.MaxSize, 5)},
+	}
+	t.Run("when strategy is not registered should return error", func(t *testing.T) {
+		require.ErrorIs(t, CheckBoard("random/easy", board.Board{}), ErrUnknownStrategy)
+	})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckBoard(tt.input, tt.board)
+			if !tt.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrUnsupportedBoard)
+			require.Contains(t, err.Error(), "sizedtest plays classic, misere boards up to 4x4")
+		})
+	}
+}
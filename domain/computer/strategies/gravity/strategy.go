@@ -0,0 +1,129 @@
+package gravity
+
+import (
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/boardhelper"
+	"tictactoe/domain/computer/strategies/mcts"
+	"tictactoe/domain/rules"
+)
+
+const defaultTimeBudget = time.Second
+
+// Strategy is a computer strategy for the gravity variant, e.g. Connect Four.
+// It wins and blocks immediate wins at once, never drops a mark under the cell the opponent wins with,
+// and chooses between the remaining columns with the Monte Carlo tree search.
+// Strategy is not safe for concurrent use.
+type Strategy struct {
+	timeBudget time.Duration
+	seed       *int64
+	search     *mcts.Strategy
+}
+
+// Option configures the Strategy.
+type Option func(*Strategy)
+
+// WithTimeBudget limits the search of every move by time, the default is one second.
+func WithTimeBudget(budget time.Duration) Option {
+	return func(s *Strategy) {
+		s.timeBudget = budget
+	}
+}
+
+// WithSeed makes the random choices reproducible.
+func WithSeed(seed int64) Option {
+	return func(s *Strategy) {
+		s.seed = &seed
+	}
+}
+
+// NewStrategy returns a new Strategy.
+func NewStrategy(opts ...Option) *Strategy {
+	s := &Strategy{
+		timeBudget: defaultTimeBudget,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	searchOpts := []mcts.Option{mcts.WithTimeBudget(s.timeBudget)}
+	if s.seed != nil {
+		searchOpts = append(searchOpts, mcts.WithSeed(*s.seed))
+	}
+	s.search = mcts.NewStrategy(searchOpts...)
+	return s
+}
+
+func init() {
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:          "gravity",
+		Name:        "Gravity",
+		Description: "wins and blocks at once, searches the safe columns with MCTS, plays Connect Four",
+		Variants:    []board.Variant{board.Gravity},
+		New: func(opts computer.StrategyOptions) computer.Strategy {
+			return NewStrategy(WithSeed(opts.Seed))
+		},
+	})
+}
+
+// String returns the string representation of the Strategy.
+func (s *Strategy) String() string {
+	return "Gravity"
+}
+
+// FindBestCellForNextTurn finds the best cell for the next turn.
+func (s *Strategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return s.FindBestCellBefore(b, time.Time{})
+}
+
+// FindBestCellBefore is like FindBestCellForNextTurn but the search also stops at the deadline unless it is zero.
+func (s *Strategy) FindBestCellBefore(b board.Board, deadline time.Time) board.Cell {
+	cells := b.PlayableCells()
+	if win := winCells(b, cells, b.CurrentTurnCellValue()); len(win) > 0 {
+		return win[0]
+	}
+	if block := winCells(b, cells, b.OpponentCellValue()); len(block) > 0 {
+		return block[0]
+	}
+	if len(cells) == 1 {
+		return cells[0]
+	}
+	if safe := safeCells(b, cells); len(safe) > 0 {
+		return s.search.FindBestCellAmong(b, safe, deadline)
+	}
+	return s.search.FindBestCellAmong(b, cells, deadline)
+}
+
+// FindBestMoveBefore is like FindBestCellBefore, the mark of the move is the one of the side to move by the rules.
+func (s *Strategy) FindBestMoveBefore(r rules.Rules, b board.Board, deadline time.Time) rules.Move {
+	return rules.MoveOf(r, b, s.FindBestCellBefore(b, deadline))
+}
+
+// winCells returns the playable cells that complete a line of the value.
+func winCells(b board.Board, cells []board.Cell, value board.CellValue) []board.Cell {
+	var result []board.Cell
+	for _, cell := range boardhelper.FindWinCellsFor(b, value) {
+		for _, c := range cells {
+			if c == cell {
+				result = append(result, cell)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// safeCells returns the playable cells that don't make playable the cell the opponent wins with.
+func safeCells(b board.Board, cells []board.Cell) []board.Cell {
+	var result []board.Cell
+	for _, cell := range cells {
+		next := b.MustSetCellValue(cell)
+		above := board.Cell{RowNumber: cell.RowNumber - 1, ColumnNumber: cell.ColumnNumber}
+		if above.RowNumber >= 0 && len(winCells(next, []board.Cell{above}, b.OpponentCellValue())) > 0 {
+			continue
+		}
+		result = append(result, cell)
+	}
+	return result
+}
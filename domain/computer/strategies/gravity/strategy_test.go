@@ -0,0 +1,69 @@
+package gravity
+
+import (
+	"github.com/stretchr/testify/require"
+	"tictactoe/domain/board"
+
+	"testing"
+	"time"
+)
+
+// connectFour returns the Connect Four board after the moves played in the columns.
+func connectFour(columns ...int) board.Board {
+	b := board.MustNewRectangular(6, 7, 4).WithVariant(board.Gravity)
+	for _, column := range columns {
+		cell, err := b.DropCell(column)
+		if err != nil {
+			panic(err)
+		}
+		b = b.MustSetCellValue(cell)
+	}
+	return b
+}
+
+func TestStrategy_FindBestCellForNextTurn(t *testing.T) {
+	tests := []struct {
+		name  string
+		board board.Board
+		want  board.Cell
+	}{
+		{
+			name:  "when the player can complete a line should win",
+			board: connectFour(0, 6, 1, 6, 2, 5),
+			want:  board.MustNewCell(5, 3),
+		},
+		{
+			name:  "when the opponent can complete a line should block it",
+			board: connectFour(6, 0, 6, 1, 5, 2),
+			want:  board.MustNewCell(5, 3),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := NewStrategy(WithSeed(1), WithTimeBudget(50*time.Millisecond))
+			got := str.FindBestCellForNextTurn(tt.board)
+			require.Equal(t, tt.want, got, "board:\n%v", tt.board)
+		})
+	}
+}
+
+func TestSafeCells(t *testing.T) {
+	// X completes the second row from the bottom at d5 once d6 is played.
+	b := connectFour(1, 0, 0, 2, 2, 6, 1)
+	t.Run("should exclude the cell under the opponent win cell", func(t *testing.T) {
+		require.NotContains(t, safeCells(b, b.PlayableCells()), board.MustNewCell(5, 3))
+		require.Len(t, safeCells(b, b.PlayableCells()), len(b.PlayableCells())-1)
+	})
+	t.Run("should not play the cell under the opponent win cell", func(t *testing.T) {
+		got := NewStrategy(WithSeed(1), WithTimeBudget(50*time.Millisecond)).FindBestCellForNextTurn(b)
+		require.NotEqual(t, board.MustNewCell(5, 3), got)
+	})
+}
+
+func TestStrategy_Seed(t *testing.T) {
+	t.Run("when the board is empty should play a playable cell", func(t *testing.T) {
+		b := connectFour()
+		got := NewStrategy(WithSeed(7), WithTimeBudget(20*time.Millisecond)).FindBestCellForNextTurn(b)
+		require.Contains(t, b.PlayableCells(), got)
+	})
+}
@@ -0,0 +1,168 @@
+package mcts
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/rules"
+	"tictactoe/domain/uct"
+)
+
+const (
+	defaultIterations  = 10000
+	defaultExploration = math.Sqrt2
+)
+
+// Strategy is a computer strategy that implements the Monte Carlo tree search
+// with the UCT (Upper Confidence bounds applied to Trees) selection.
+// Random playouts make it work on boards of any size, the budget defines its strength.
+// Strategy is not safe for concurrent use.
+type Strategy struct {
+	iterations  int
+	timeBudget  time.Duration
+	exploration float64
+	rnd         *rand.Rand
+}
+
+// Option configures the Strategy.
+type Option func(*Strategy)
+
+// WithIterations limits the search by the count of iterations.
+func WithIterations(iterations int) Option {
+	return func(s *Strategy) {
+		s.iterations = iterations
+	}
+}
+
+// WithTimeBudget limits the search by time.
+func WithTimeBudget(budget time.Duration) Option {
+	return func(s *Strategy) {
+		s.timeBudget = budget
+	}
+}
+
+// WithSeed makes the random choices reproducible.
+func WithSeed(seed int64) Option {
+	return func(s *Strategy) {
+		s.rnd = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithExploration sets the UCT exploration constant, the higher it is the wider the tree is.
+func WithExploration(c float64) Option {
+	return func(s *Strategy) {
+		s.exploration = c
+	}
+}
+
+// NewStrategy returns a new Strategy.
+// The search stops when any of the iterations and time budgets is exhausted.
+// Without budgets the search is limited by 10000 iterations.
+// Without a seed the random choices differ from run to run.
+func NewStrategy(opts ...Option) *Strategy {
+	s := &Strategy{
+		exploration: defaultExploration,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.iterations == 0 && s.timeBudget == 0 {
+		s.iterations = defaultIterations
+	}
+	if s.rnd == nil {
+		s.rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return s
+}
+
+func init() {
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:          "mcts",
+		Name:        "Monte Carlo Tree Search",
+		Description: "runs random playouts guided by UCT, plays on boards of any size",
+		Variants:    []board.Variant{board.Classic, board.Misere, board.Gravity},
+		New: func(opts computer.StrategyOptions) computer.Strategy {
+			return NewStrategy(WithSeed(opts.Seed))
+		},
+	})
+}
+
+// String returns the string representation of the Strategy.
+func (s *Strategy) String() string {
+	return "MCTS"
+}
+
+// FindBestCellForNextTurn finds the best cell for the next turn: the most visited one.
+func (s *Strategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return s.FindBestCellAmong(b, b.PlayableCells(), time.Time{})
+}
+
+// FindBestCellBefore is like FindBestCellForNextTurn but the search also stops at the deadline.
+func (s *Strategy) FindBestCellBefore(b board.Board, deadline time.Time) board.Cell {
+	return s.FindBestCellAmong(b, b.PlayableCells(), deadline)
+}
+
+// FindBestMoveBefore is like FindBestCellBefore, the mark of the move is the one of the side to move by the rules.
+func (s *Strategy) FindBestMoveBefore(r rules.Rules, b board.Board, deadline time.Time) rules.Move {
+	return rules.MoveOf(r, b, s.FindBestCellBefore(b, deadline))
+}
+
+// FindBestCellAmong is like FindBestCellBefore but searches only the given playable cells at the first move,
+// so other strategies can exclude the moves they know to be bad.
+// The zero deadline means the search is limited only by the budgets of the strategy.
+// It returns the zero cell if there are no cells to search or the game is over.
+func (s *Strategy) FindBestCellAmong(b board.Board, cells []board.Cell, deadline time.Time) board.Cell {
+	bb := b.Bitboard()
+	moves := make([]int, len(cells))
+	for i, cell := range cells {
+		moves[i] = bb.Index(cell)
+	}
+	if s.timeBudget > 0 {
+		if budget := time.Now().Add(s.timeBudget); deadline.IsZero() || budget.Before(deadline) {
+			deadline = budget
+		}
+	}
+	c := uct.Config{Iterations: s.iterations, Deadline: deadline, Exploration: s.exploration, Rand: s.rnd}
+	best, ok := uct.Search(c, position{bb: bb}, moves)
+	if !ok {
+		return board.Cell{}
+	}
+	return bb.Cell(best)
+}
+
+// position is the bitboard searched by UCT, the moves are the indexes of the cells.
+type position struct {
+	bb board.Bitboard
+}
+
+// AppendLegalMoves appends the indexes of the playable cells.
+func (p position) AppendLegalMoves(dst []int) []int {
+	if p.bb.IsCompleted() {
+		return dst
+	}
+	return p.bb.AppendPlayable(dst)
+}
+
+// Play returns the position after the move.
+func (p position) Play(i int) position {
+	return position{bb: p.bb.Play(i)}
+}
+
+// IsTerminal returns true if the board is completed.
+func (p position) IsTerminal() bool {
+	return p.bb.IsCompleted()
+}
+
+// Winner returns the winner of the board.
+func (p position) Winner() board.CellValue {
+	w, _ := p.bb.Winner()
+	return w
+}
+
+// SideToMove returns the current turn cell value.
+func (p position) SideToMove() board.CellValue {
+	return p.bb.CurrentTurnCellValue()
+}
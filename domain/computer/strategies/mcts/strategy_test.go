@@ -0,0 +1,149 @@
+package mcts
+
+import (
+	"github.com/stretchr/testify/require"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer/strategies/minimax"
+
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestStrategy_findBestCellForNextTurn(t *testing.T) {
+	tests := []struct {
+		name  string
+		board board.Board
+		want  []int
+	}{
+		{
+			name: "If the player has two in a row, they can place a third to get three in a row",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.OValue, board.OValue, board.XValue},
+				{board.EmptyValue, board.XValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			want: []int{2, 0},
+		},
+		{
+			name: "If the opponent has two in a row, the player must play the third themselves to block the opponent.",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.OValue, board.EmptyValue, board.XValue},
+				{board.EmptyValue, board.XValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			want: []int{2, 0},
+		},
+		{
+			name: "At second turn if center cell is empty should return center cell",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			want: []int{1, 1},
+		},
+		{
+			name: "At 5th should create fork if possible",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.OValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.XValue, board.OValue},
+				{board.EmptyValue, board.EmptyValue, board.XValue},
+			}),
+			want: []int{2, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			str := NewStrategy(WithSeed(1))
+			got := str.FindBestCellForNextTurn(tt.board)
+
+			expectedBoard := tt.board.MustSetCellValue(board.Cell{
+				RowNumber:    tt.want[0],
+				ColumnNumber: tt.want[1],
+			})
+			actualBoard := tt.board.MustSetCellValue(got)
+			require.Equal(t, tt.want, []int{got.RowNumber, got.ColumnNumber},
+				fmt.Sprintf("expected boardhelper:\n%vactual boardhelper:\n%v", expectedBoard, actualBoard),
+			)
+		})
+	}
+}
+
+func TestStrategy_Seed(t *testing.T) {
+	t.Run("when seed is the same should return the same cells", func(t *testing.T) {
+		first := NewStrategy(WithSeed(7), WithIterations(50))
+		second := NewStrategy(WithSeed(7), WithIterations(50))
+		b := board.MustNew(5, 4)
+		for i := 0; i < 5; i++ {
+			c := first.FindBestCellForNextTurn(b)
+			require.Equal(t, c, second.FindBestCellForNextTurn(b))
+			b = b.MustSetCellValue(c)
+		}
+	})
+}
+
+func TestStrategy_TimeBudget(t *testing.T) {
+	t.Run("should stop the search when the time budget is exhausted", func(t *testing.T) {
+		str := NewStrategy(WithSeed(1), WithTimeBudget(50*time.Millisecond))
+		start := time.Now()
+		got := str.FindBestCellForNextTurn(board.MustNew(15, 5))
+		require.Less(t, time.Since(start), time.Second)
+		require.True(t, board.MustNew(15, 5).IsEmptyCell(got))
+	})
+}
+
+func TestStrategy_FindBestCellBefore(t *testing.T) {
+	t.Run("should stop the search at the deadline", func(t *testing.T) {
+		str := NewStrategy(WithSeed(1), WithTimeBudget(time.Hour))
+		start := time.Now()
+		got := str.FindBestCellBefore(board.MustNew(15, 5), start.Add(50*time.Millisecond))
+		require.Less(t, time.Since(start), time.Second)
+		require.True(t, board.MustNew(15, 5).IsEmptyCell(got))
+	})
+}
+
+func TestStrategy_AgainstMinimax(t *testing.T) {
+	t.Run("should never lose against minimax", func(t *testing.T) {
+		for _, mctsIsX := range []bool{true, false} {
+			str := NewStrategy(WithSeed(3))
+			perfect := minimax.NewStrategy()
+			b := board.Board{}
+			for !b.IsCompleted() {
+				mctsTurn := (b.CurrentTurnCellValue() == board.XValue) == mctsIsX
+				if mctsTurn {
+					b = b.MustSetCellValue(str.FindBestCellForNextTurn(b))
+				} else {
+					b = b.MustSetCellValue(perfect.FindBestCellForNextTurn(b))
+				}
+			}
+			_, hasWinner := b.Winner()
+			require.False(t, hasWinner, fmt.Sprintf("\nboard:\n%v", b))
+		}
+	})
+}
+
+func TestStrategy_FindBestCellAmong(t *testing.T) {
+	t.Run("should choose only from the given cells", func(t *testing.T) {
+		b := board.MustNewFromRows([][]board.CellValue{
+			{board.OValue, board.OValue, board.XValue},
+			{board.EmptyValue, board.XValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		cells := []board.Cell{board.MustNewCell(1, 0), board.MustNewCell(2, 2)}
+		got := NewStrategy(WithSeed(1), WithIterations(200)).FindBestCellAmong(b, cells, time.Time{})
+		require.Contains(t, cells, got)
+	})
+	t.Run("when there are no cells should return the zero cell", func(t *testing.T) {
+		got := NewStrategy(WithSeed(1), WithIterations(200)).FindBestCellAmong(board.Board{}, nil, time.Time{})
+		require.Equal(t, board.Cell{}, got)
+	})
+	t.Run("when the game is over should return the zero cell", func(t *testing.T) {
+		b := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, board.XValue},
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		require.Equal(t, board.Cell{}, NewStrategy(WithSeed(1), WithIterations(200)).FindBestCellForNextTurn(b))
+	})
+}
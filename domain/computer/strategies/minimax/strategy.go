@@ -2,17 +2,87 @@ package minimax
 
 import (
 	"math"
+	"time"
 
 	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/rules"
 )
 
-// Strategy is a computer strategy that implements the minimax algorithm.
+const (
+	// WinScore is the score of a win at the root, every ply to the win decreases it by one,
+	// so the engine prefers faster wins and slower losses.
+	WinScore = 1000
+	// minWinScore is the lowest score of a win, it is reached on the biggest board.
+	minWinScore = WinScore - board.MaxSize*board.MaxSize
+)
+
+// Strategy is a computer strategy that implements the minimax algorithm
+// with alpha-beta pruning and a transposition table.
+// It searches the moves of the rules of the board variant unless other rules are given.
 type Strategy struct {
+	// rules are the searched rules, nil means the rules of the board variant.
+	rules              rules.Rules
+	alphaBeta          bool
+	transpositionTable bool
+	maxDepth           int
+}
+
+// Option configures the Strategy.
+type Option func(*Strategy)
+
+// WithoutAlphaBeta disables alpha-beta pruning.
+func WithoutAlphaBeta() Option {
+	return func(s *Strategy) {
+		s.alphaBeta = false
+	}
+}
+
+// WithoutTranspositionTable disables the transposition table.
+func WithoutTranspositionTable() Option {
+	return func(s *Strategy) {
+		s.transpositionTable = false
+	}
+}
+
+// WithMaxDepth limits the search depth in plies, positions at the limit are scored as a draw.
+// Zero means the search is not limited.
+func WithMaxDepth(depth int) Option {
+	return func(s *Strategy) {
+		s.maxDepth = depth
+	}
+}
+
+// WithRules makes the strategy search the moves of the rules.
+func WithRules(r rules.Rules) Option {
+	return func(s *Strategy) {
+		s.rules = r
+	}
 }
 
 // NewStrategy returns a new Strategy.
-func NewStrategy() *Strategy {
-	return &Strategy{}
+func NewStrategy(opts ...Option) *Strategy {
+	s := &Strategy{
+		alphaBeta:          true,
+		transpositionTable: true,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func init() {
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:           "minimax",
+		Name:         "Minimax",
+		Description:  "searches the whole game tree with alpha-beta pruning, never loses",
+		Variants:     []board.Variant{board.Classic, board.Misere, board.Wild},
+		MaxBoardSize: 4,
+		New: func(computer.StrategyOptions) computer.Strategy {
+			return NewStrategy()
+		},
+	})
 }
 
 // String returns the string representation of the Strategy.
@@ -20,67 +90,335 @@ func (s *Strategy) String() string {
 	return "Minimax"
 }
 
+// Stats contains statistics of a search.
+type Stats struct {
+	// Nodes is the count of searched positions.
+	Nodes int
+	// TableHits is the count of positions whose score was taken from the transposition table.
+	TableHits int
+	// Duration is the search time.
+	Duration time.Duration
+}
+
 // FindBestCellForNextTurn finds the best cell for the next turn.
 func (s *Strategy) FindBestCellForNextTurn(b board.Board) board.Cell {
-	bestVal := math.MinInt64
-	var bestMove board.Cell
-	boardSize := len(b)
-	playerCellValue := b.CurrentTurnCellValue()
-	opponentCellValue := b.OpponentCellValue()
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if b[i][j].IsEmpty() {
-				b[i][j] = playerCellValue
-				moveVal := minimax(b, 0, false, playerCellValue, opponentCellValue)
-				b[i][j] = board.EmptyValue
-
-				if moveVal > bestVal {
-					bestMove = board.MustNewCell(i, j)
-					bestVal = moveVal
-				}
-			}
+	cell, _ := s.FindBestCellWithStats(b)
+	return cell
+}
+
+// FindBestCellWithStats finds the best cell for the next turn and returns the search statistics.
+// If several cells have the same score, the first one in row-major order is returned.
+func (s *Strategy) FindBestCellWithStats(b board.Board) (board.Cell, Stats) {
+	m, stats := s.findBestMove(s.rulesOf(b), b)
+	return m.Cell, stats
+}
+
+// FindBestMove finds the best move for the next turn by the rules instead of the strategy ones.
+// In the wild variant it chooses the mark too.
+func (s *Strategy) FindBestMove(r rules.Rules, b board.Board) rules.Move {
+	m, _ := s.findBestMove(r, b)
+	return m
+}
+
+// findBestMove finds the best move by the rules, the first one of the legal moves if several have the same score.
+func (s *Strategy) findBestMove(r rules.Rules, b board.Board) (rules.Move, Stats) {
+	start := time.Now()
+	sr := s.newSearcher(r, b)
+	bestVal := math.MinInt
+	var bestMove rules.Move
+	alpha, beta := -math.MaxInt, math.MaxInt
+	for _, m := range r.LegalMoves(b) {
+		moveVal := sr.searchMove(b, m, alpha, beta)
+		if moveVal > bestVal {
+			bestMove = m
+			bestVal = moveVal
+		}
+		if s.alphaBeta {
+			alpha = max(alpha, moveVal)
 		}
 	}
-	return bestMove
+	sr.stats.Duration = time.Since(start)
+	return bestMove, sr.stats
+}
+
+// CellScore is the score of playing the cell for the side to move.
+type CellScore struct {
+	Cell board.Cell
+	// Mark is the placed mark, it is the mark of the side to move unless the variant is wild.
+	Mark  board.CellValue
+	Score int
+}
+
+// ScoreCells returns the exact scores of all legal moves in row-major order.
+// A positive score is a win and a negative score is a loss for the side to move, zero is a draw.
+// The absolute score of a win or a loss is WinScore minus the count of plies to the end of the game,
+// including the scored move. With the max depth the scores are exact only for the searched plies.
+func (s *Strategy) ScoreCells(b board.Board) []CellScore {
+	r := s.rulesOf(b)
+	sr := s.newSearcher(r, b)
+	moves := r.LegalMoves(b)
+	scores := make([]CellScore, 0, len(moves))
+	for _, m := range moves {
+		// Every move is searched with the full window, so its score is exact.
+		v := sr.searchMove(b, m, -math.MaxInt, math.MaxInt)
+		scores = append(scores, CellScore{Cell: m.Cell, Mark: m.Mark, Score: v})
+	}
+	return scores
+}
+
+// rulesOf returns the rules the strategy searches on the board.
+func (s *Strategy) rulesOf(b board.Board) rules.Rules {
+	if s.rules != nil {
+		return s.rules
+	}
+	return rules.For(b.Variant())
+}
+
+// searcher holds the state of a single search.
+type searcher struct {
+	strategy *Strategy
+	rules    rules.Rules
+	// bitboard is true if the rules are the built-in ones that agree with the board variant,
+	// so the search runs on bitboards.
+	bitboard bool
+	// wild is true if the rules are the wild ones, so both marks are searched.
+	wild bool
+	// cells are the buffers of playable cells by ply.
+	cells [][]int
+	table map[positionKey]tableEntry
+	stats Stats
 }
 
-// minimax is the minimax algorithm.
-func minimax(b board.Board, depth int, isMax bool, playerCellValue, opponentCellValue board.CellValue) int {
-	boardSize := len(b)
-	if cellValue, ex := b.Winner(); ex {
-		if cellValue == playerCellValue {
-			return 10
+// newSearcher returns a new searcher of the moves of the rules on the board.
+// Bitboards decide the winner by the board variant, so the wild rules are searched on bitboards
+// only on boards of the Wild variant.
+func (s *Strategy) newSearcher(r rules.Rules, b board.Board) searcher {
+	sr := searcher{strategy: s, rules: r}
+	switch r.(type) {
+	case rules.Classic:
+		sr.bitboard = true
+	case rules.Wild:
+		sr.wild = true
+		sr.bitboard = b.Variant() == board.Wild
+	}
+	if s.transpositionTable {
+		sr.table = make(map[positionKey]tableEntry)
+	}
+	return sr
+}
+
+// searchMove returns the score of the move on the root board for the side to move.
+func (sr *searcher) searchMove(b board.Board, m rules.Move, alpha, beta int) int {
+	if sr.bitboard {
+		bb := b.Bitboard()
+		return -sr.searchBitboard(bb.Place(bb.Index(m.Cell), m.Mark), 1, -beta, -alpha)
+	}
+	return -sr.search(rules.MustApply(sr.rules, b, m), 1, -beta, -alpha)
+}
+
+// search is the minimax algorithm in the negamax form with alpha-beta pruning.
+// It returns the score of the board for the current turn player.
+// ply is the count of moves from the root of the search.
+func (sr *searcher) search(b board.Board, ply int, alpha, beta int) int {
+	sr.stats.Nodes++
+	if w, ex := sr.rules.Result(b); ex {
+		return winScore(w == sr.rules.SideToMove(b), ply)
+	}
+	if sr.rules.IsTerminal(b) {
+		return 0
+	}
+	if sr.strategy.maxDepth > 0 && ply >= sr.strategy.maxDepth {
+		return 0
+	}
+	if !sr.strategy.alphaBeta {
+		alpha, beta = -math.MaxInt, math.MaxInt
+	}
+
+	depth := sr.depthOf(len(b.EmptyCells()), ply)
+	var key positionKey
+	if sr.table != nil {
+		key = keyOf(b.Bitboard())
+		if v, ok := sr.probe(key, depth, ply, alpha, beta); ok {
+			return v
 		}
-		return -10
 	}
-	if b.IsFull() {
+
+	alphaOrig := alpha
+	best := math.MinInt
+	for _, m := range sr.rules.LegalMoves(b) {
+		v := -sr.search(rules.MustApply(sr.rules, b, m), ply+1, -beta, -alpha)
+		best = max(best, v)
+		alpha = max(alpha, v)
+		if sr.strategy.alphaBeta && alpha >= beta {
+			break
+		}
+	}
+
+	if sr.table != nil {
+		sr.store(key, best, alphaOrig, beta, depth, ply)
+	}
+	return best
+}
+
+// wildMarks are the marks of every wild move, xMarks and oMarks are the marks of the classic moves by the side.
+var (
+	wildMarks = []board.CellValue{board.XValue, board.OValue}
+	xMarks    = []board.CellValue{board.XValue}
+	oMarks    = []board.CellValue{board.OValue}
+)
+
+// searchBitboard is search on the bitboard by the built-in rules, it doesn't allocate boards and moves.
+func (sr *searcher) searchBitboard(bb board.Bitboard, ply int, alpha, beta int) int {
+	sr.stats.Nodes++
+	if w, ex := bb.Winner(); ex {
+		return winScore(w == bb.CurrentTurnCellValue(), ply)
+	}
+	if bb.IsFull() {
+		return 0
+	}
+	if sr.strategy.maxDepth > 0 && ply >= sr.strategy.maxDepth {
 		return 0
 	}
+	if !sr.strategy.alphaBeta {
+		alpha, beta = -math.MaxInt, math.MaxInt
+	}
 
-	if isMax {
-		best := math.MinInt64
-		for i := 0; i < boardSize; i++ {
-			for j := 0; j < boardSize; j++ {
-				if b[i][j].IsEmpty() {
-					b[i][j] = playerCellValue
-					best = max(best, minimax(b, depth+1, !isMax, playerCellValue, opponentCellValue))
-					b[i][j] = board.EmptyValue
-				}
-			}
+	depth := sr.depthOf(bb.Rows()*bb.Columns()-bb.FullCellsCount(), ply)
+	var key positionKey
+	if sr.table != nil {
+		key = keyOf(bb)
+		if v, ok := sr.probe(key, depth, ply, alpha, beta); ok {
+			return v
 		}
-		return best
-	} else {
-		best := math.MaxInt64
-
-		for i := 0; i < boardSize; i++ {
-			for j := 0; j < boardSize; j++ {
-				if b[i][j].IsEmpty() {
-					b[i][j] = opponentCellValue
-					best = min(best, minimax(b, depth+1, !isMax, playerCellValue, opponentCellValue))
-					b[i][j] = board.EmptyValue
-				}
+	}
+
+	marks := xMarks
+	switch {
+	case sr.wild:
+		marks = wildMarks
+	case bb.CurrentTurnCellValue() == board.OValue:
+		marks = oMarks
+	}
+	for len(sr.cells) <= ply {
+		sr.cells = append(sr.cells, nil)
+	}
+	cells := bb.AppendPlayable(sr.cells[ply][:0])
+	sr.cells[ply] = cells
+
+	alphaOrig := alpha
+	best := math.MinInt
+search:
+	for _, i := range cells {
+		for _, mark := range marks {
+			v := -sr.searchBitboard(bb.Place(i, mark), ply+1, -beta, -alpha)
+			best = max(best, v)
+			alpha = max(alpha, v)
+			if sr.strategy.alphaBeta && alpha >= beta {
+				break search
 			}
 		}
-		return best
 	}
+
+	if sr.table != nil {
+		sr.store(key, best, alphaOrig, beta, depth, ply)
+	}
+	return best
+}
+
+// winScore returns the score of the decided game for the side to move.
+func winScore(won bool, ply int) int {
+	if won {
+		return WinScore - ply
+	}
+	return -(WinScore - ply)
+}
+
+// depthOf returns the depth of the search below the position with the count of empty cells.
+func (sr *searcher) depthOf(empty, ply int) int {
+	if sr.strategy.maxDepth > 0 {
+		return min(empty, sr.strategy.maxDepth-ply)
+	}
+	return empty
+}
+
+// probe returns the score of the position from the transposition table if it decides the search.
+func (sr *searcher) probe(key positionKey, depth, ply, alpha, beta int) (int, bool) {
+	e, ok := sr.table[key]
+	if !ok || e.depth < depth {
+		return 0, false
+	}
+	v := fromTableScore(e.score, ply)
+	switch {
+	case e.bound == boundExact,
+		e.bound == boundLower && v >= beta,
+		e.bound == boundUpper && v <= alpha:
+		sr.stats.TableHits++
+		return v, true
+	}
+	return 0, false
+}
+
+// store stores the best score of the position searched with the window from alphaOrig to beta.
+func (sr *searcher) store(key positionKey, best, alphaOrig, beta, depth, ply int) {
+	e := tableEntry{score: toTableScore(best, ply), depth: depth, bound: boundExact}
+	switch {
+	case best <= alphaOrig:
+		e.bound = boundUpper
+	case best >= beta:
+		e.bound = boundLower
+	}
+	sr.table[key] = e
+}
+
+// bound describes how the stored score relates to the real score of a position.
+type bound int
+
+const (
+	boundExact bound = iota
+	// boundLower means the real score is greater than or equal to the stored one.
+	boundLower
+	// boundUpper means the real score is less than or equal to the stored one.
+	boundUpper
+)
+
+// tableEntry is an entry of the transposition table.
+type tableEntry struct {
+	score int
+	depth int
+	bound bound
+}
+
+// toTableScore converts the score relative to the root to the score relative to the position,
+// so the same position reached at different plies shares the entry.
+func toTableScore(score, ply int) int {
+	switch {
+	case score >= minWinScore:
+		return score + ply
+	case score <= -minWinScore:
+		return score - ply
+	}
+	return score
+}
+
+// fromTableScore converts the score relative to the position to the score relative to the root.
+func fromTableScore(score, ply int) int {
+	switch {
+	case score >= minWinScore:
+		return score - ply
+	case score <= -minWinScore:
+		return score + ply
+	}
+	return score
+}
+
+// positionKey is a collision free hash of a position: one bit per cell for each player.
+type positionKey struct {
+	x board.Mask
+	o board.Mask
+}
+
+// keyOf returns the position key of the bitboard.
+func keyOf(bb board.Bitboard) positionKey {
+	x, o := bb.Masks()
+	return positionKey{x: x, o: o}
 }
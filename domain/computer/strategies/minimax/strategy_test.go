@@ -3,6 +3,7 @@ package minimax
 import (
 	"github.com/stretchr/testify/require"
 	"tictactoe/domain/board"
+	"tictactoe/domain/rules"
 
 	"fmt"
 	"testing"
@@ -16,11 +17,11 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 	}{
 		{
 			name: "2. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.OValue},
-			},
+			}),
 			want: []int{0, 0},
 		},
 		{
@@ -30,110 +31,110 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 		},
 		{
 			name: "At second turn if center cell is empty should return center cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{1, 1},
 		},
 		{
 			name: "At second turn if center cell is not empty should return corner cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{0, 0},
 		},
 		{
 			name: "1. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		{
 			name: "2. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.XValue, board.XValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{1, 0},
 		},
 		{
 			name: "3. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "4. At 4th turn if opponent has win situation even if center is empty should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.OValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "At 4th turn if opponent doesn't have win situation and center is empty and center can allow opponent to create a fork shouldn't return center cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		{
 			name: "1. At 5th should create fork if possible",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "2. At 5th should create fork if possible",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.XValue},
-			},
+			}),
 			want: []int{0, 2},
 		},
 		{
 			name: "Avoid fork",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: []int{0, 1},
 		},
 		{
 			name: "Last turns",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.OValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.OValue, board.XValue, board.OValue},
-			},
+			}),
 			want: []int{1, 0},
 		},
 		{
 			name: "If the opponent has two in a row, the player must play the third themselves to block the opponent.",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 	}
@@ -153,3 +154,237 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 		})
 	}
 }
+
+func TestStrategy_findBestCellForNextTurn_PrefersFasterWin(t *testing.T) {
+	// (1, 0) also wins by creating a fork, but (2, 2) wins right now.
+	b := board.MustNewFromRows([][]board.CellValue{
+		{board.XValue, board.OValue, board.OValue},
+		{board.EmptyValue, board.XValue, board.EmptyValue},
+		{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+	})
+	got := NewStrategy().FindBestCellForNextTurn(b)
+	require.Equal(t, board.MustNewCell(2, 2), got)
+}
+
+func TestStrategy_findBestCellForNextTurn_PrefersSlowerLoss(t *testing.T) {
+	// O can't stop X fork on the next turn, but it should block the immediate win.
+	b := board.MustNewFromRows([][]board.CellValue{
+		{board.XValue, board.OValue, board.EmptyValue},
+		{board.EmptyValue, board.XValue, board.EmptyValue},
+		{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+	})
+	got := NewStrategy().FindBestCellForNextTurn(b)
+	require.Equal(t, board.MustNewCell(2, 2), got)
+}
+
+func TestStrategy_findBestCellForNextTurn_Misere(t *testing.T) {
+	t.Run("when other cells complete a line should play the only safe cell", func(t *testing.T) {
+		b := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.OValue, board.XValue},
+			{board.OValue, board.XValue, board.OValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		}).WithVariant(board.Misere)
+		got := NewStrategy().FindBestCellForNextTurn(b)
+		require.Equal(t, board.MustNewCell(2, 1), got)
+	})
+	t.Run("when both sides play perfectly should end in a draw", func(t *testing.T) {
+		s := NewStrategy()
+		b := board.Board{}.WithVariant(board.Misere)
+		for !b.IsCompleted() {
+			b = b.MustSetCellValue(s.FindBestCellForNextTurn(b))
+		}
+		_, ok := b.Winner()
+		require.False(t, ok, fmt.Sprintf("\nboard:\n%v", b))
+	})
+}
+
+func TestStrategy_findBestMove_Wild(t *testing.T) {
+	t.Run("when the line can be completed with the other mark should play it", func(t *testing.T) {
+		b, err := rules.Wild{}.Apply(board.Board{}.WithVariant(board.Wild), rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.OValue})
+		require.NoError(t, err)
+		b = rules.MustApply(rules.Wild{}, b, rules.Move{Cell: board.MustNewCell(0, 1), Mark: board.OValue})
+		got := NewStrategy().FindBestMove(rules.Wild{}, b)
+		require.Equal(t, rules.Move{Cell: board.MustNewCell(0, 2), Mark: board.OValue}, got)
+	})
+	t.Run("when the board is classic should search by the wild rules", func(t *testing.T) {
+		b := board.MustNewFromRows([][]board.CellValue{
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.XValue, board.EmptyValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.XValue},
+		})
+		got := NewStrategy().FindBestMove(rules.Wild{}, b)
+		require.Equal(t, rules.Move{Cell: board.MustNewCell(0, 2), Mark: board.OValue}, got)
+	})
+	t.Run("when both sides play perfectly should be won by the first player", func(t *testing.T) {
+		s := NewStrategy()
+		b := board.Board{}.WithVariant(board.Wild)
+		for !b.IsCompleted() {
+			b = rules.MustApply(rules.Wild{}, b, s.FindBestMove(rules.Wild{}, b))
+		}
+		w, ok := b.Winner()
+		require.True(t, ok)
+		require.Equal(t, board.XValue, w, fmt.Sprintf("\nboard:\n%v", b))
+	})
+}
+
+// lineLoses are the rules where completing a line loses, played on the classic board.
+type lineLoses struct {
+	rules.Classic
+}
+
+func (lineLoses) Result(b board.Board) (board.CellValue, bool) {
+	w, ok := b.Winner()
+	return -w, ok
+}
+
+func TestStrategy_FindBestMove(t *testing.T) {
+	b := board.MustNewFromRows([][]board.CellValue{
+		{board.XValue, board.OValue, board.XValue},
+		{board.OValue, board.XValue, board.OValue},
+		{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+	})
+	t.Run("should search the moves by the given rules", func(t *testing.T) {
+		got := NewStrategy().FindBestMove(lineLoses{}, b)
+		require.Equal(t, rules.Move{Cell: board.MustNewCell(2, 1), Mark: board.XValue}, got)
+	})
+	t.Run("should search the moves by the rules of the strategy", func(t *testing.T) {
+		require.Equal(t, board.MustNewCell(2, 1), NewStrategy(WithRules(lineLoses{})).FindBestCellForNextTurn(b))
+		require.NotEqual(t, board.MustNewCell(2, 1), NewStrategy().FindBestCellForNextTurn(b))
+	})
+}
+
+func TestStrategy_FindBestCellWithStats(t *testing.T) {
+	tests := []struct {
+		name  string
+		board board.Board
+	}{
+		{
+			name:  "At first turn",
+			board: board.Board{},
+		},
+		{
+			name: "At second turn",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+		},
+		{
+			name: "At 5th turn",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.OValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.XValue, board.OValue},
+				{board.EmptyValue, board.EmptyValue, board.XValue},
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" pruning and transposition table should search less nodes with the same result", func(t *testing.T) {
+			plainCell, plainStats := NewStrategy(WithoutAlphaBeta(), WithoutTranspositionTable()).FindBestCellWithStats(tt.board)
+			prunedCell, prunedStats := NewStrategy(WithoutTranspositionTable()).FindBestCellWithStats(tt.board)
+			cell, stats := NewStrategy().FindBestCellWithStats(tt.board)
+
+			require.Equal(t, plainCell, prunedCell)
+			require.Equal(t, plainCell, cell)
+			require.Zero(t, plainStats.TableHits)
+			require.Less(t, prunedStats.Nodes, plainStats.Nodes)
+			require.Less(t, stats.Nodes, prunedStats.Nodes)
+			require.Positive(t, stats.TableHits)
+		})
+	}
+}
+
+func TestStrategy_findBestCellForNextTurn_SizedBoard(t *testing.T) {
+	t.Run("should win on 4x4 board", func(t *testing.T) {
+		b := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.OValue, board.EmptyValue, board.EmptyValue},
+			{board.OValue, board.XValue, board.EmptyValue, board.EmptyValue},
+			{board.EmptyValue, board.OValue, board.XValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		got := NewStrategy().FindBestCellForNextTurn(b)
+		require.Equal(t, board.MustNewCell(3, 3), got)
+	})
+	t.Run("should block with limited depth on 7x7 board", func(t *testing.T) {
+		b, err := board.New(7, 4)
+		require.NoError(t, err)
+		for _, c := range [][]int{{3, 3}, {3, 6}, {3, 4}, {0, 0}, {3, 5}} {
+			b = b.MustSetCellValue(board.MustNewCell(c[0], c[1]))
+		}
+		got := NewStrategy(WithMaxDepth(2)).FindBestCellForNextTurn(b)
+		require.Equal(t, board.MustNewCell(3, 2), got)
+	})
+}
+
+func TestStrategy_ScoreCells(t *testing.T) {
+	b := board.MustNewFromRows([][]board.CellValue{
+		{board.XValue, board.XValue, board.EmptyValue},
+		{board.OValue, board.OValue, board.EmptyValue},
+		{board.XValue, board.OValue, board.EmptyValue},
+	})
+	want := []CellScore{
+		{Cell: board.MustNewCell(0, 2), Mark: board.XValue, Score: WinScore - 1},
+		{Cell: board.MustNewCell(1, 2), Mark: board.XValue, Score: 0},
+		{Cell: board.MustNewCell(2, 2), Mark: board.XValue, Score: -(WinScore - 2)},
+	}
+	for _, s := range []*Strategy{NewStrategy(), NewStrategy(WithoutAlphaBeta(), WithoutTranspositionTable())} {
+		require.Equal(t, want, s.ScoreCells(b))
+	}
+}
+
+// boardRules are the classic rules searched on boards instead of bitboards.
+type boardRules struct {
+	rules.Classic
+}
+
+// boardWildRules are the wild rules searched on boards instead of bitboards.
+type boardWildRules struct {
+	rules.Wild
+}
+
+func TestStrategy_Bitboard(t *testing.T) {
+	tests := []struct {
+		name  string
+		board board.Board
+		rules rules.Rules
+		opts  []Option
+	}{
+		{name: "when the variant is classic", board: board.Board{}, rules: boardRules{}},
+		{name: "when the variant is misere", board: board.Board{}.WithVariant(board.Misere), rules: boardRules{}},
+		{name: "when the variant is wild", board: board.Board{}.WithVariant(board.Wild).MustSetCellValue(board.MustNewCell(1, 1)), rules: boardWildRules{}},
+		{name: "when the board is 4x4 with the max depth", board: board.MustNew(4, 3), rules: boardRules{}, opts: []Option{WithMaxDepth(4)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+" should score cells like the board search", func(t *testing.T) {
+			want := NewStrategy(append(tt.opts, WithRules(tt.rules))...).ScoreCells(tt.board)
+			require.Equal(t, want, NewStrategy(tt.opts...).ScoreCells(tt.board))
+		})
+	}
+}
+
+func BenchmarkStrategy_FindBestCellForNextTurn(b *testing.B) {
+	benchmarks := []struct {
+		name  string
+		board board.Board
+		opts  []Option
+	}{
+		{name: "3x3", board: board.Board{}},
+		{name: "3x3 without transposition table", board: board.Board{}, opts: []Option{WithoutTranspositionTable()}},
+		{name: "4x4", board: board.MustNew(4, 3), opts: []Option{WithMaxDepth(6)}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name+"/board", func(b *testing.B) {
+			s := NewStrategy(append(bm.opts, WithRules(boardRules{}))...)
+			for i := 0; i < b.N; i++ {
+				s.FindBestCellForNextTurn(bm.board)
+			}
+		})
+		b.Run(bm.name+"/bitboard", func(b *testing.B) {
+			s := NewStrategy(bm.opts...)
+			for i := 0; i < b.N; i++ {
+				s.FindBestCellForNextTurn(bm.board)
+			}
+		})
+	}
+}
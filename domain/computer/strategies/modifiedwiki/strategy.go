@@ -2,6 +2,7 @@ package modifiedwiki
 
 import (
 	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
 	"tictactoe/domain/computer/boardhelper"
 	"tictactoe/domain/computer/strategies/wiki"
 )
@@ -22,31 +23,55 @@ func NewStrategy() *Strategy {
 	}
 }
 
+func init() {
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:           "modifiedwiki",
+		Name:         "Modified Wiki",
+		Description:  "plays the Wikipedia rules with less predictable openings",
+		MaxBoardSize: 3,
+		New: func(computer.StrategyOptions) computer.Strategy {
+			return NewStrategy()
+		},
+	})
+}
+
 // String returns the string representation of the Strategy.
 func (s *Strategy) String() string {
 	return "ModifiedWiki"
 }
 
+// Opening rules of the Strategy that take precedence over the wiki rules in the first 3 turns.
+const (
+	RuleOpeningCenter = "Opening center"
+	RuleQuietCorner   = "Quiet corner"
+)
+
 // FindBestCellForNextTurn finds the best cell for the next turn.
 func (s *Strategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return s.ExplainBestCellForNextTurn(b).Cell
+}
+
+// ExplainBestCellForNextTurn finds the best cell for the next turn and explains the rule that has chosen it.
+func (s *Strategy) ExplainBestCellForNextTurn(b board.Board) computer.Explanation {
 	turnNumber := b.FullCellsCount() + 1
 	if turnNumber <= 3 { // It is my own Strategy for first 3 turns,
 		// I think it is more win Strategy than wiki algorithm.
-		if b.IsEmptyCell(b.MidCell()) {
-			return b.MidCell()
+		if mid := b.MidCell(); b.IsEmptyCell(mid) {
+			return computer.Explanation{Cell: mid, Rule: RuleOpeningCenter, Considered: []board.Cell{mid}}
 		}
 		// Return corner that do not create win situation for current player,
 		// Because it is too predictable for opponent, and it leads to the draw.
-		if cell := findEmptyCornerThatNotCreateWinSituation(b); cell != nil {
-			return *cell
+		if cells := findEmptyCornersThatNotCreateWinSituation(b); len(cells) > 0 {
+			return computer.Explanation{Cell: cells[0], Rule: RuleQuietCorner, Considered: cells}
 		}
 	}
-	return s.Strategy.FindBestCellForNextTurn(b)
+	return s.Strategy.ExplainBestCellForNextTurn(b)
 }
 
-// findEmptyCornerThatNotCreateWinSituation returns the empty corner that not create 2 in a line.
+// findEmptyCornersThatNotCreateWinSituation returns the empty corners that not create 2 in a line.
 // Because it is too predictable for opponent, and it leads to the draw.
-func findEmptyCornerThatNotCreateWinSituation(b board.Board) *board.Cell {
+func findEmptyCornersThatNotCreateWinSituation(b board.Board) []board.Cell {
+	var cells []board.Cell
 	corners := b.Corners()
 	for _, corner := range corners {
 		if !b.IsEmptyCell(corner) {
@@ -58,7 +83,7 @@ func findEmptyCornerThatNotCreateWinSituation(b board.Board) *board.Cell {
 		if c, _ := boardhelper.FindWinSituationsFor(nextBoard, b.CurrentTurnCellValue()); c != nil {
 			continue
 		}
-		return &corner
+		cells = append(cells, corner)
 	}
-	return nil
+	return cells
 }
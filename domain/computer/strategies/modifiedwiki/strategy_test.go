@@ -3,6 +3,7 @@ package modifiedwiki
 import (
 	"github.com/stretchr/testify/require"
 	"tictactoe/domain/board"
+	"tictactoe/domain/computer/strategies/wiki"
 
 	"fmt"
 	"testing"
@@ -16,57 +17,57 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 	}{
 		{
 			name: "At third turn if center is not yours and corner is not taken by you should return any empty corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{0, 2},
 		},
 		{
 			name: "At third turn if center is not yours and corner is taken by you should return opposite corner without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		// cases from wiki strategies
 		{
 			name: "1. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		{
 			name: "2. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.OValue},
-			},
+			}),
 			want: []int{0, 0},
 		},
 		{
 			name: "3. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.OValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{0, 2},
 		},
 		{
 			name: "4. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
@@ -76,119 +77,119 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 		},
 		{
 			name: "At second turn if center cell is empty should return center cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{1, 1},
 		},
 		{
 			name: "At second turn if center cell is not empty should return corner cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{0, 0},
 		},
 		{
 			name: "1. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		{
 			name: "2. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.XValue, board.XValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{1, 0},
 		},
 		{
 			name: "3. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "4. At 4th turn if opponent has win situation even if center is empty should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.OValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "At 4th turn if opponent doesn't have win situation and center is empty and center can allow opponent to create a fork shouldn't return center cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		{
 			name: "1. At 5th should create fork if possible",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "2. At 5th should create fork if possible",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.XValue},
-			},
+			}),
 			want: []int{0, 2},
 		},
 		{
 			name: "Avoid fork",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: []int{0, 1},
 		},
 		{
 			name: "Last turns",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.OValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.OValue, board.XValue, board.OValue},
-			},
+			}),
 			want: []int{1, 0},
 		},
 		{
 			name: "If the player has two in a row, they can place a third to get three in a row",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.OValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "If the opponent has two in a row, the player must play the third themselves to block the opponent.",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 	}
@@ -208,3 +209,51 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 		})
 	}
 }
+
+func TestStrategy_ExplainBestCellForNextTurn(t *testing.T) {
+	tests := []struct {
+		name           string
+		board          board.Board
+		wantCell       board.Cell
+		wantRule       string
+		wantConsidered []board.Cell
+	}{
+		{
+			name:           "At first turn should explain the opening center",
+			board:          board.Board{},
+			wantCell:       board.MustNewCell(1, 1),
+			wantRule:       RuleOpeningCenter,
+			wantConsidered: []board.Cell{board.MustNewCell(1, 1)},
+		},
+		{
+			name: "At third turn if center is not yours should explain the corner without win situation",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+				{board.XValue, board.OValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			wantCell:       board.MustNewCell(0, 2),
+			wantRule:       RuleQuietCorner,
+			wantConsidered: []board.Cell{board.MustNewCell(0, 2), board.MustNewCell(2, 2)},
+		},
+		{
+			name: "After third turn should explain the wiki rule",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.OValue, board.OValue, board.EmptyValue},
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+			}),
+			wantCell:       board.MustNewCell(1, 2),
+			wantRule:       wiki.RuleBlock,
+			wantConsidered: []board.Cell{board.MustNewCell(1, 2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewStrategy().ExplainBestCellForNextTurn(tt.board)
+			require.Equal(t, tt.wantCell, got.Cell, fmt.Sprintf("\nboard:\n%v", tt.board))
+			require.Equal(t, tt.wantRule, got.Rule)
+			require.Equal(t, tt.wantConsidered, got.Considered)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+// Package strategies registers all built-in computer strategies,
+// import it for side effects to enumerate them with computer.Strategies.
+package strategies
+
+import (
+	// Strategies register themselves in init functions.
+	_ "tictactoe/domain/computer/openingbook"
+	_ "tictactoe/domain/computer/strategies/gravity"
+	_ "tictactoe/domain/computer/strategies/mcts"
+	_ "tictactoe/domain/computer/strategies/minimax"
+	_ "tictactoe/domain/computer/strategies/modifiedwiki"
+	_ "tictactoe/domain/computer/strategies/tablebase"
+	_ "tictactoe/domain/computer/strategies/wiki"
+)
@@ -0,0 +1,49 @@
+package tablebase
+
+import (
+	_ "embed"
+	"sync"
+
+	"tictactoe/domain/board"
+)
+
+//go:generate go run ../../../../cmd/tictactoe tablebase -variant classic -o classic.tb
+//go:generate go run ../../../../cmd/tictactoe tablebase -variant misere -o misere.tb
+
+var (
+	//go:embed classic.tb
+	classicData []byte
+	//go:embed misere.tb
+	misereData []byte
+)
+
+// embedded are the tablebases decoded from the embedded files on the first use.
+var embedded = struct {
+	once    sync.Once
+	classic *Tablebase
+	misere  *Tablebase
+}{}
+
+// For returns the embedded tablebase of the variant.
+func For(v board.Variant) (*Tablebase, error) {
+	embedded.once.Do(func() {
+		embedded.classic = mustDecode(classicData)
+		embedded.misere = mustDecode(misereData)
+	})
+	switch v {
+	case board.Classic:
+		return embedded.classic, nil
+	case board.Misere:
+		return embedded.misere, nil
+	}
+	return nil, ErrUnsupportedVariant
+}
+
+// mustDecode decodes the embedded tablebase, it panics because a broken file is a build error.
+func mustDecode(data []byte) *Tablebase {
+	t := &Tablebase{}
+	if err := t.UnmarshalBinary(data); err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -0,0 +1,62 @@
+package tablebase
+
+import (
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/minimax"
+)
+
+// Strategy is a computer strategy that plays the first best move of the embedded tablebase in O(1).
+// Boards the tablebase doesn't cover are played by the fallback strategy, minimax by default.
+type Strategy struct {
+	fallback computer.Strategy
+}
+
+// Option configures the Strategy.
+type Option func(*Strategy)
+
+// WithFallback sets the strategy of the boards the tablebase doesn't cover.
+func WithFallback(fallback computer.Strategy) Option {
+	return func(s *Strategy) {
+		s.fallback = fallback
+	}
+}
+
+// NewStrategy returns a new Strategy.
+func NewStrategy(opts ...Option) *Strategy {
+	s := &Strategy{
+		fallback: minimax.NewStrategy(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func init() {
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:           "tablebase",
+		Name:         "Tablebase",
+		Description:  "looks up the perfect move of every 3x3 position in a precomputed table, never loses",
+		Variants:     []board.Variant{board.Classic, board.Misere},
+		MaxBoardSize: 4,
+		New: func(computer.StrategyOptions) computer.Strategy {
+			return NewStrategy()
+		},
+	})
+}
+
+// String returns the string representation of the Strategy.
+func (s *Strategy) String() string {
+	return "Tablebase"
+}
+
+// FindBestCellForNextTurn finds the best cell for the next turn.
+func (s *Strategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	if t, err := For(b.Variant()); err == nil {
+		if e, ok := t.Lookup(b); ok && len(e.BestMoves) > 0 {
+			return e.BestMoves[0]
+		}
+	}
+	return s.fallback.FindBestCellForNextTurn(b)
+}
@@ -0,0 +1,205 @@
+// Package tablebase solves every position of the 3x3 board once and stores the perfect-play outcomes
+// and best moves in a compact binary file that is embedded into the program,
+// so the perfect move of any position is looked up instead of searched.
+package tablebase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"tictactoe/domain/analysis"
+	"tictactoe/domain/board"
+)
+
+var (
+	// ErrUnsupportedVariant is returned when a tablebase of the variant can't be generated.
+	ErrUnsupportedVariant = errors.New("unsupported variant, tablebases are generated for the classic and misere variants")
+	// ErrInvalidTablebase is returned when the data is not a tablebase.
+	ErrInvalidTablebase = errors.New("invalid tablebase data")
+)
+
+const (
+	// Size is the size of the boards covered by tablebases.
+	Size = board.DefaultSize
+	// positions is the count of boards of 3x3 cells with every cell empty, X or O.
+	positions = 19683
+	// version is the version of the file format.
+	version = 1
+)
+
+// magic starts every tablebase file.
+var magic = [4]byte{'T', 'T', 'T', 'B'}
+
+// Entry is the perfect-play solution of a position.
+type Entry struct {
+	// Outcome is the outcome of the position for the side to move.
+	Outcome analysis.Outcome
+	// Plies is the count of moves of both sides until the forced win or loss, it is zero in a draw
+	// and in a completed position.
+	Plies int
+	// BestMoves are the cells of the moves that keep the outcome in row-major order,
+	// they win fastest and lose slowest. They are empty in a completed position.
+	BestMoves []board.Cell
+}
+
+// Tablebase holds the entries of every position reachable from the empty board by legal moves.
+// The entry of a position is a single word at the index of the position:
+// the bits 0-8 are the best cells in row-major order, the bits 9-10 are the outcome
+// stored as Loss, Draw and Win from 1 to 3 with 0 marking unreachable positions,
+// the bits 11-14 are the plies.
+type Tablebase struct {
+	variant board.Variant
+	entries [positions]uint16
+}
+
+// Generate solves every position of the variant reachable from the empty 3x3 board.
+func Generate(v board.Variant) (*Tablebase, error) {
+	if v != board.Classic && v != board.Misere {
+		return nil, ErrUnsupportedVariant
+	}
+	t := &Tablebase{variant: v}
+	if err := t.solve(board.Board{}.WithVariant(v)); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// solve stores the entry of the position and of every position reachable from it.
+func (t *Tablebase) solve(b board.Board) error {
+	i := indexOf(b)
+	if t.entries[i] != 0 {
+		return nil
+	}
+	if b.IsCompleted() {
+		e := Entry{Outcome: analysis.Draw}
+		if w, ok := b.Winner(); ok {
+			e.Outcome = analysis.Loss
+			if w == b.CurrentTurnCellValue() {
+				e.Outcome = analysis.Win
+			}
+		}
+		t.entries[i] = encode(e)
+		return nil
+	}
+	a, err := analysis.Analyze(b)
+	if err != nil {
+		return err
+	}
+	best := a.Best()
+	e := Entry{Outcome: best.Outcome, Plies: best.Plies}
+	for _, m := range a.Moves {
+		if !best.Better(m) {
+			e.BestMoves = append(e.BestMoves, m.Cell)
+		}
+		if err := t.solve(b.MustSetCellValue(m.Cell)); err != nil {
+			return err
+		}
+	}
+	t.entries[i] = encode(e)
+	return nil
+}
+
+// Variant returns the variant of the solved positions.
+func (t *Tablebase) Variant() board.Variant {
+	return t.variant
+}
+
+// Len returns the count of solved positions.
+func (t *Tablebase) Len() int {
+	n := 0
+	for _, e := range t.entries {
+		if e != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Lookup returns the entry of the position in O(1).
+// It returns false if the board is not a 3x3 board of the variant with the win length 3
+// or the position is not reachable by legal moves.
+func (t *Tablebase) Lookup(b board.Board) (Entry, bool) {
+	if !t.Covers(b) {
+		return Entry{}, false
+	}
+	w := t.entries[indexOf(b)]
+	if w == 0 {
+		return Entry{}, false
+	}
+	return decode(w), true
+}
+
+// Covers returns true if the board has the dimensions, the win length and the variant of the tablebase.
+func (t *Tablebase) Covers(b board.Board) bool {
+	return b.Rows() == Size && b.Columns() == Size && b.WinLength() == Size && b.Variant() == t.variant
+}
+
+// MarshalBinary encodes the tablebase: the magic "TTTB", the version and the variant bytes
+// followed by the little-endian entries of all positions.
+func (t *Tablebase) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(magic[:])
+	buf.WriteByte(version)
+	buf.WriteByte(byte(t.variant))
+	if err := binary.Write(&buf, binary.LittleEndian, t.entries); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes the tablebase encoded by MarshalBinary.
+func (t *Tablebase) UnmarshalBinary(data []byte) error {
+	header := len(magic) + 2
+	if len(data) != header+2*positions || !bytes.Equal(data[:len(magic)], magic[:]) || data[len(magic)] != version {
+		return ErrInvalidTablebase
+	}
+	t.variant = board.Variant(data[len(magic)+1])
+	if t.variant != board.Classic && t.variant != board.Misere {
+		return ErrInvalidTablebase
+	}
+	return binary.Read(bytes.NewReader(data[header:]), binary.LittleEndian, &t.entries)
+}
+
+// indexOf returns the index of the position: the cells in row-major order as digits of a base 3 number,
+// 0 for an empty cell, 1 for X and 2 for O.
+func indexOf(b board.Board) int {
+	i := 0
+	for row := Size - 1; row >= 0; row-- {
+		for column := Size - 1; column >= 0; column-- {
+			i *= 3
+			switch b.CellValue(board.Cell{RowNumber: row, ColumnNumber: column}) {
+			case board.XValue:
+				i++
+			case board.OValue:
+				i += 2
+			}
+		}
+	}
+	return i
+}
+
+// encode packs the entry into the word stored in the tablebase.
+func encode(e Entry) uint16 {
+	var w uint16
+	for _, cell := range e.BestMoves {
+		w |= 1 << (cell.RowNumber*Size + cell.ColumnNumber)
+	}
+	w |= uint16(e.Outcome+2) << 9
+	w |= uint16(e.Plies) << 11
+	return w
+}
+
+// decode unpacks the entry from the word stored in the tablebase.
+func decode(w uint16) Entry {
+	e := Entry{
+		Outcome: analysis.Outcome(w>>9&3) - 2,
+		Plies:   int(w >> 11 & 15),
+	}
+	for i := 0; i < Size*Size; i++ {
+		if w&(1<<i) != 0 {
+			e.BestMoves = append(e.BestMoves, board.Cell{RowNumber: i / Size, ColumnNumber: i % Size})
+		}
+	}
+	return e
+}
@@ -0,0 +1,147 @@
+package tablebase
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/analysis"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer/strategies/minimax"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, v := range []board.Variant{board.Classic, board.Misere} {
+		t.Run("when the variant is "+v.String()+" should match the embedded tablebase", func(t *testing.T) {
+			generated, err := Generate(v)
+			require.NoError(t, err)
+			require.Equal(t, 5478, generated.Len())
+			embedded, err := For(v)
+			require.NoError(t, err)
+			require.Equal(t, generated, embedded, "the embedded tablebase is outdated, run go generate")
+		})
+	}
+	t.Run("when the variant is not supported should return error", func(t *testing.T) {
+		_, err := Generate(board.Gravity)
+		require.ErrorIs(t, err, ErrUnsupportedVariant)
+	})
+}
+
+func TestTablebase_Lookup(t *testing.T) {
+	classic, err := For(board.Classic)
+	require.NoError(t, err)
+	tests := []struct {
+		name string
+		b    board.Board
+		want Entry
+	}{
+		{
+			name: "when the board is empty should be a draw with every cell best",
+			b:    board.Board{},
+			want: Entry{Outcome: analysis.Draw, BestMoves: board.Board{}.EmptyCells()},
+		},
+		{
+			name: "when the side to move has two in a row should win at once",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.XValue, board.EmptyValue},
+				{board.OValue, board.OValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			want: Entry{Outcome: analysis.Win, Plies: 1, BestMoves: []board.Cell{board.MustNewCell(0, 2)}},
+		},
+		{
+			name: "when the game is won should be a loss for the side to move",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.XValue, board.XValue},
+				{board.OValue, board.OValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			want: Entry{Outcome: analysis.Loss},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := classic.Lookup(tt.b)
+			require.True(t, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+	t.Run("when the position is unreachable should return false", func(t *testing.T) {
+		_, ok := classic.Lookup(board.MustNewFromRows([][]board.CellValue{
+			{board.OValue, board.EmptyValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		}))
+		require.False(t, ok)
+	})
+	t.Run("when the board is not covered should return false", func(t *testing.T) {
+		_, ok := classic.Lookup(board.MustNew(4, 3))
+		require.False(t, ok)
+		_, ok = classic.Lookup(board.Board{}.WithVariant(board.Misere))
+		require.False(t, ok)
+	})
+	t.Run("should agree with the analysis", func(t *testing.T) {
+		b := board.Board{}.MustSetCellValue(board.MustNewCell(0, 0)).MustSetCellValue(board.MustNewCell(0, 1))
+		a, err := analysis.Analyze(b)
+		require.NoError(t, err)
+		got, ok := classic.Lookup(b)
+		require.True(t, ok)
+		require.Equal(t, a.Outcome(), got.Outcome)
+		require.Equal(t, a.Best().Plies, got.Plies)
+		require.Contains(t, got.BestMoves, a.Best().Cell)
+	})
+}
+
+func TestTablebase_UnmarshalBinary(t *testing.T) {
+	misere, err := For(board.Misere)
+	require.NoError(t, err)
+	data, err := misere.MarshalBinary()
+	require.NoError(t, err)
+	t.Run("should decode the encoded tablebase", func(t *testing.T) {
+		got := &Tablebase{}
+		require.NoError(t, got.UnmarshalBinary(data))
+		require.Equal(t, misere, got)
+	})
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "when the data is truncated should return error", data: data[:100]},
+		{name: "when the magic is wrong should return error", data: append([]byte("XXXX"), data[4:]...)},
+		{name: "when the variant is unknown should return error", data: append(bytes.Clone(data[:5]), append([]byte{9}, data[6:]...)...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, (&Tablebase{}).UnmarshalBinary(tt.data), ErrInvalidTablebase)
+		})
+	}
+}
+
+func TestStrategy_FindBestCellForNextTurn(t *testing.T) {
+	t.Run("should never lose against minimax", func(t *testing.T) {
+		for _, v := range []board.Variant{board.Classic, board.Misere} {
+			for _, tablebaseFirst := range []bool{true, false} {
+				b := board.Board{}.WithVariant(v)
+				tablebaseTurn := board.XValue
+				if !tablebaseFirst {
+					tablebaseTurn = board.OValue
+				}
+				for !b.IsCompleted() {
+					if b.CurrentTurnCellValue() == tablebaseTurn {
+						b = b.MustSetCellValue(NewStrategy().FindBestCellForNextTurn(b))
+						continue
+					}
+					b = b.MustSetCellValue(minimax.NewStrategy().FindBestCellForNextTurn(b))
+				}
+				w, _ := b.Winner()
+				require.NotEqual(t, -tablebaseTurn, w, "\nboard:\n%v", b)
+			}
+		}
+	})
+	t.Run("when the board is not covered should play the fallback strategy", func(t *testing.T) {
+		b := board.MustNew(4, 3)
+		require.Equal(t, minimax.NewStrategy(minimax.WithMaxDepth(2)).FindBestCellForNextTurn(b),
+			NewStrategy(WithFallback(minimax.NewStrategy(minimax.WithMaxDepth(2)))).FindBestCellForNextTurn(b))
+	})
+}
@@ -7,82 +7,89 @@ import (
 
 // findPossibleFork returns the cell that will create fork against opponent.
 func findPossibleFork(b board.Board) *board.Cell {
-	boardSize := len(b)
-	count := b.FullCellsCount()
-	if count > boardSize*boardSize-3 { // it is already end  part of the game, so the player can't create a fork
+	if !canCreateFork(b) {
 		return nil
 	}
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if !b[i][j].IsEmpty() {
-				continue
-			}
-			nextBoard := b.MustSetCellValue(board.Cell{RowNumber: i, ColumnNumber: j})
-			if _, count := boardhelper.FindWinSituationsFor(
-				nextBoard,
-				b.CurrentTurnCellValue(),
-			); count > 1 {
-				return &board.Cell{RowNumber: i, ColumnNumber: j}
-			}
+	for _, cell := range b.EmptyCells() {
+		if createsFork(b, cell) {
+			return &cell
 		}
 	}
 	return nil
 }
 
-// findCellToBlockPossibleOpponentForks returns a cell to block possible opponent forks.
-// If there are no forks, returns nil.
+// findPossibleForks returns all cells that will create fork against opponent.
+func findPossibleForks(b board.Board) []board.Cell {
+	if !canCreateFork(b) {
+		return nil
+	}
+	var cells []board.Cell
+	for _, cell := range b.EmptyCells() {
+		if createsFork(b, cell) {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+// canCreateFork returns false at the end part of the game, when the player can't create a fork.
+func canCreateFork(b board.Board) bool {
+	boardSize := b.Size()
+	return b.FullCellsCount() <= boardSize*boardSize-3
+}
+
+// createsFork returns true if playing the cell creates two win situations for the current player.
+func createsFork(b board.Board, cell board.Cell) bool {
+	nextBoard := b.MustSetCellValue(cell)
+	_, count := boardhelper.FindWinSituationsFor(nextBoard, b.CurrentTurnCellValue())
+	return count > 1
+}
+
+// findCellToBlockPossibleOpponentForks returns a cell to block possible opponent forks and the candidate cells it is chosen from.
+// If there are no forks or the forks can't be blocked, returns nil.
 // If there is only one possible fork for the opponent, the player should block it.
 // Otherwise, the player should block all forks in any way that simultaneously allows them to make two in a row.
 // Otherwise, the player should make a two in a row to force the opponent into defending, as long as it does not result in them producing a fork.
-func findCellToBlockPossibleOpponentForks(b board.Board) *board.Cell {
-	boardSize := len(b)
-	count := b.FullCellsCount()
-	if count > boardSize*boardSize-3 { // it is already end part of the game, so the opponent can't create a fork
-		return nil
+func findCellToBlockPossibleOpponentForks(b board.Board) (*board.Cell, []board.Cell) {
+	if !canCreateFork(b) { // it is already end part of the game, so the opponent can't create a fork
+		return nil, nil
 	}
 	opponentCanCreateFork := false
-	var winCell, possibleCell *board.Cell
-	for i := 0; i < boardSize; i++ {
-		for j := 0; j < boardSize; j++ {
-			if !b[i][j].IsEmpty() {
-				continue
-			}
-			cell := board.Cell{RowNumber: i, ColumnNumber: j}
-			opponentBoard := b.MustSetCellValue(cell)
-			opponentForkCell := findPossibleFork(opponentBoard)
-			if opponentForkCell != nil {
-				opponentCanCreateFork = true
-			} else { // opponent can't create a fork, remember this cell
-				possibleCell = &cell
-			}
-			if winCell == nil {
-				// try to find a cell that will create a win situation(2 in line) for current player
-				// and force opponent to block it
-				// and opponent's turn will not create a fork against current player
-				c, _ := boardhelper.FindWinSituationsFor(opponentBoard, b.CurrentTurnCellValue())
-				if c == nil {
-					continue
-				}
-				nextCurrentPlayerBoard := opponentBoard.MustSetCellValue(*c)
-				if _, count := boardhelper.FindWinSituationsFor(
-					nextCurrentPlayerBoard,
-					b.OpponentCellValue(),
-				); count < 2 { // opponent can't create a fork, remember this cell
-					winCell = &cell
-				}
-			}
-
+	var winCells, possibleCells []board.Cell
+	for _, cell := range b.EmptyCells() {
+		opponentBoard := b.MustSetCellValue(cell)
+		opponentForkCell := findPossibleFork(opponentBoard)
+		if opponentForkCell != nil {
+			opponentCanCreateFork = true
+		} else { // opponent can't create a fork, remember this cell
+			possibleCells = append(possibleCells, cell)
+		}
+		// try to find a cell that will create a win situation(2 in line) for current player
+		// and force opponent to block it
+		// and opponent's turn will not create a fork against current player
+		c, _ := boardhelper.FindWinSituationsFor(opponentBoard, b.CurrentTurnCellValue())
+		if c == nil {
+			continue
+		}
+		nextCurrentPlayerBoard := opponentBoard.MustSetCellValue(*c)
+		if _, count := boardhelper.FindWinSituationsFor(
+			nextCurrentPlayerBoard,
+			b.OpponentCellValue(),
+		); count < 2 { // opponent can't create a fork, remember this cell
+			winCells = append(winCells, cell)
 		}
 	}
 	if !opponentCanCreateFork {
-		return nil
+		return nil, nil
 	}
 	// Opponent can create fork, we should block it
-	if winCell != nil {
-		return winCell
+	if len(winCells) > 0 {
+		return &winCells[0], winCells
 	}
-	if possibleCell != nil {
-		return possibleCell
+	// If every cell allows the opponent to create a fork, the game is lost anyway,
+	// so the next rules choose the cell.
+	if len(possibleCells) > 0 {
+		return &possibleCells[len(possibleCells)-1], possibleCells
 	}
-	panic("opponent can create fork but we can't block it. It is unpredictable situation, fix the code")
+	return nil, nil
 }
@@ -16,29 +16,29 @@ func TestFindPossibleFork(t *testing.T) {
 	}{
 		{
 			name: "1. when Board don't have possible fork should return nil",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: nil,
 		},
 		{
 			name: "2. when Board don't have possible fork should return nil",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: nil,
 		},
 		{
 			name: "1. when Board has possible fork should return cell",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: &board.Cell{
 				RowNumber:    0,
 				ColumnNumber: 2,
@@ -46,11 +46,11 @@ func TestFindPossibleFork(t *testing.T) {
 		},
 		{
 			name: "2. when Board has possible fork should return cell",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: &board.Cell{
 				RowNumber:    2,
 				ColumnNumber: 0,
@@ -58,11 +58,11 @@ func TestFindPossibleFork(t *testing.T) {
 		},
 		{
 			name: "3. when Board has possible fork should return cell",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.XValue},
-			},
+			}),
 			want: &board.Cell{
 				RowNumber:    0,
 				ColumnNumber: 2,
@@ -70,11 +70,11 @@ func TestFindPossibleFork(t *testing.T) {
 		},
 		{
 			name: "4. when Board has possible fork should return cell",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.XValue, board.XValue},
 				{board.EmptyValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: &board.Cell{
 				RowNumber:    1,
 				ColumnNumber: 0,
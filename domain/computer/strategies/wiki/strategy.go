@@ -2,6 +2,7 @@ package wiki
 
 import (
 	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
 	"tictactoe/domain/computer/boardhelper"
 )
 
@@ -14,58 +15,92 @@ func NewStrategy() *Strategy {
 	return &Strategy{}
 }
 
+func init() {
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:           "wiki",
+		Name:         "Wiki",
+		Description:  "plays the rules from the Wikipedia article: win, block, fork, block fork, center, corners, sides",
+		MaxBoardSize: 3,
+		New: func(computer.StrategyOptions) computer.Strategy {
+			return NewStrategy()
+		},
+	})
+}
+
 // String returns the string representation of the Strategy.
 func (s *Strategy) String() string {
 	return "Wiki"
 }
 
+// Rules of the Strategy in the order of priority.
+const (
+	RuleWin            = "Win"
+	RuleBlock          = "Block"
+	RuleFork           = "Fork"
+	RuleBlockFork      = "Block fork"
+	RuleCenter         = "Center"
+	RuleOppositeCorner = "Opposite corner"
+	RuleEmptyCorner    = "Empty corner"
+	RuleEmptySide      = "Empty side"
+)
+
 // FindBestCellForNextTurn finds the best cell for the next turn.
 func (s *Strategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return s.ExplainBestCellForNextTurn(b).Cell
+}
+
+// ExplainBestCellForNextTurn finds the best cell for the next turn and explains the rule that has chosen it.
+func (s *Strategy) ExplainBestCellForNextTurn(b board.Board) computer.Explanation {
 	// Win: If the player has two in a row, they can place a third to get three in a row.
 	if cell, _ := boardhelper.FindWinSituationsFor(b, b.CurrentTurnCellValue()); cell != nil {
-		return *cell
+		return explain(*cell, RuleWin, boardhelper.FindWinCellsFor(b, b.CurrentTurnCellValue()))
 	}
 	// Block: If the opponent has two in a row, the player must play the third themselves to block the opponent.
 	if cell, _ := boardhelper.FindWinSituationsFor(b, b.OpponentCellValue()); cell != nil {
-		return *cell
+		return explain(*cell, RuleBlock, boardhelper.FindWinCellsFor(b, b.OpponentCellValue()))
 	}
 	// Fork: Cause a scenario where the player has two ways to win (two non-blocked lines of 2).
-	if cell := findPossibleFork(b); cell != nil {
-		return *cell
+	if cells := findPossibleForks(b); len(cells) > 0 {
+		return explain(cells[0], RuleFork, cells)
 	}
 
 	// Blocking an opponent's fork.
-	if cell := findCellToBlockPossibleOpponentForks(b); cell != nil {
-		return *cell
+	if cell, candidates := findCellToBlockPossibleOpponentForks(b); cell != nil {
+		return explain(*cell, RuleBlockFork, candidates)
 	}
 
 	// Center: A player marks the center.
 	// As long as it does not result in them producing a fork against current player.
 	if mid := b.MidCell(); b.IsEmptyCell(mid) {
-		return mid
+		return explain(mid, RuleCenter, []board.Cell{mid})
 	}
 
 	// Opposite corner: If the opponent is in the corner, the player plays the opposite corner.
 	if cell := findOppositeEmptyCorner(b); cell != nil {
-		return *cell
+		return explain(*cell, RuleOppositeCorner, []board.Cell{*cell})
 	}
 
 	// Empty corner: The player plays in a corner square.
-	if cell := findEmptyCorner(b); cell != nil {
-		return *cell
+	if cells := emptyCells(b, b.Corners()); len(cells) > 0 {
+		return explain(cells[0], RuleEmptyCorner, cells)
 	}
 
 	// Empty side: The player plays in a middle square on any of the 4 sides.
-	if cell := findEmptySide(b); cell != nil {
-		return *cell
+	if cells := emptyCells(b, b.SideCells()); len(cells) > 0 {
+		return explain(cells[0], RuleEmptySide, cells)
 	}
 	panic("can't find best cell for next turn, it is impossible, fix the code")
 }
 
+// explain returns the explanation of the cell chosen by the rule.
+func explain(cell board.Cell, rule string, considered []board.Cell) computer.Explanation {
+	return computer.Explanation{Cell: cell, Rule: rule, Considered: considered}
+}
+
 // findOppositeEmptyCorner returns the opposite empty corner to opponent corner.
 // If there are no opposite empty corner, returns nil.
 func findOppositeEmptyCorner(b board.Board) *board.Cell {
-	boardSize := len(b)
+	boardSize := b.Size()
 	cellBottomRight := board.MustNewCell(boardSize-1, boardSize-1)
 	cellTopLeft := board.MustNewCell(0, 0)
 	if b.CellValue(cellTopLeft) == b.OpponentCellValue() &&
@@ -83,30 +118,19 @@ func findOppositeEmptyCorner(b board.Board) *board.Cell {
 		return &cellBottomLeft
 	}
 	if b.CellValue(cellTopRight).IsEmpty() &&
-		b[boardSize-1][0] == b.OpponentCellValue() {
+		b.CellValue(cellBottomLeft) == b.OpponentCellValue() {
 		return &cellTopRight
 	}
 	return nil
 }
 
-// findEmptyCorner returns the empty corner.
-func findEmptyCorner(b board.Board) *board.Cell {
-	corners := b.Corners()
-	for _, cell := range corners {
-		if b[cell.RowNumber][cell.ColumnNumber].IsEmpty() {
-			return &cell
-		}
-	}
-	return nil
-}
-
-// findEmptySide returns the empty side.
-func findEmptySide(b board.Board) *board.Cell {
-	cells := b.SideCells()
+// emptyCells returns the empty cells of the cells in the same order.
+func emptyCells(b board.Board, cells []board.Cell) []board.Cell {
+	var empty []board.Cell
 	for _, cell := range cells {
-		if b[cell.RowNumber][cell.ColumnNumber].IsEmpty() {
-			return &cell
+		if b.IsEmptyCell(cell) {
+			empty = append(empty, cell)
 		}
 	}
-	return nil
+	return empty
 }
@@ -16,57 +16,57 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 	}{
 		{
 			name: "At third turn if center is not yours and corner is not taken by you should return any empty corner cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{0, 0},
 		},
 		{
 			name: "At third turn if center is not yours and corner is taken by you should return opposite corner",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{0, 2},
 		},
 		// behavior like in modified wiki strategy:
 		{
 			name: "1. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		{
 			name: "2. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.OValue},
-			},
+			}),
 			want: []int{0, 0},
 		},
 		{
 			name: "3. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.OValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{0, 2},
 		},
 		{
 			name: "4. At third turn should return corner cell without win situation because it is too predictable",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
@@ -76,119 +76,119 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 		},
 		{
 			name: "At second turn if center cell is empty should return center cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{1, 1},
 		},
 		{
 			name: "At second turn if center cell is not empty should return corner cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{0, 0},
 		},
 		{
 			name: "1. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		{
 			name: "2. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.XValue, board.XValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{1, 0},
 		},
 		{
 			name: "3. At 4th turn if opponent has win situation should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "4. At 4th turn if opponent has win situation even if center is empty should return cell to block it",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.OValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "At 4th turn if opponent doesn't have win situation and center is empty and center can allow opponent to create a fork shouldn't return center cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 2},
 		},
 		{
 			name: "1. At 5th should create fork if possible",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.OValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "2. At 5th should create fork if possible",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.XValue},
-			},
+			}),
 			want: []int{0, 2},
 		},
 		{
 			name: "Avoid fork",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.OValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
-			},
+			}),
 			want: []int{0, 1},
 		},
 		{
 			name: "Last turns",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.OValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.OValue, board.XValue, board.OValue},
-			},
+			}),
 			want: []int{1, 0},
 		},
 		{
 			name: "If the player has two in a row, they can place a third to get three in a row",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.OValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 		{
 			name: "If the opponent has two in a row, the player must play the third themselves to block the opponent.",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.OValue, board.EmptyValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			want: []int{2, 0},
 		},
 	}
@@ -208,3 +208,91 @@ func TestStrategy_findBestCellForNextTurn(t *testing.T) {
 		})
 	}
 }
+
+func TestStrategy_findBestCellForNextTurn_AllPositions(t *testing.T) {
+	t.Run("should return an explained empty cell for every reachable position", func(t *testing.T) {
+		str := NewStrategy()
+		seen := map[board.Board]bool{}
+		var walk func(b board.Board)
+		walk = func(b board.Board) {
+			if seen[b] || b.IsCompleted() {
+				return
+			}
+			seen[b] = true
+			got := str.FindBestCellForNextTurn(b)
+			require.True(t, b.IsEmptyCell(got), fmt.Sprintf("\nboard:\n%v", b))
+			e := str.ExplainBestCellForNextTurn(b)
+			require.Equal(t, got, e.Cell, fmt.Sprintf("\nboard:\n%v", b))
+			require.Contains(t, e.Considered, got, fmt.Sprintf("\nboard:\n%v", b))
+			for _, cell := range b.EmptyCells() {
+				walk(b.MustSetCellValue(cell))
+			}
+		}
+		walk(board.Board{})
+	})
+}
+
+func TestStrategy_ExplainBestCellForNextTurn(t *testing.T) {
+	tests := []struct {
+		name           string
+		board          board.Board
+		wantCell       board.Cell
+		wantRule       string
+		wantConsidered []board.Cell
+	}{
+		{
+			name: "when the player has two in a row should explain the win",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.XValue, board.EmptyValue},
+				{board.OValue, board.OValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			wantCell:       board.MustNewCell(0, 2),
+			wantRule:       RuleWin,
+			wantConsidered: []board.Cell{board.MustNewCell(0, 2)},
+		},
+		{
+			name: "when the opponent has two in a row should explain the block",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.OValue, board.OValue, board.EmptyValue},
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+			}),
+			wantCell:       board.MustNewCell(1, 2),
+			wantRule:       RuleBlock,
+			wantConsidered: []board.Cell{board.MustNewCell(1, 2)},
+		},
+		{
+			name: "when the center is empty and nothing else fires should explain the center",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			wantCell:       board.MustNewCell(1, 1),
+			wantRule:       RuleCenter,
+			wantConsidered: []board.Cell{board.MustNewCell(1, 1)},
+		},
+		{
+			name: "when the center is taken by the opponent should explain the empty corner with all empty corners",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.XValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			wantCell: board.MustNewCell(0, 0),
+			wantRule: RuleEmptyCorner,
+			wantConsidered: []board.Cell{
+				board.MustNewCell(0, 0), board.MustNewCell(0, 2), board.MustNewCell(2, 0), board.MustNewCell(2, 2),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewStrategy().ExplainBestCellForNextTurn(tt.board)
+			require.Equal(t, tt.wantCell, got.Cell, fmt.Sprintf("\nboard:\n%v", tt.board))
+			require.Equal(t, tt.wantRule, got.Rule)
+			require.Equal(t, tt.wantConsidered, got.Considered)
+		})
+	}
+}
@@ -1,8 +1,23 @@
 package game
 
 import (
+	"errors"
 	"fmt"
 	"tictactoe/domain/board"
+	"tictactoe/domain/clock"
+	"tictactoe/domain/rules"
+	"time"
+)
+
+var (
+	// ErrNothingToUndo is returned when there are no moves to undo.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo is returned when there are no undone moves to redo.
+	ErrNothingToRedo = errors.New("nothing to redo")
+	// ErrTimeIsUp is returned when a move is played after the flag of the player has fallen.
+	ErrTimeIsUp = errors.New("time is up, the flag has fallen")
+	// ErrTimedGame is returned when a move of a timed game is taken back.
+	ErrTimedGame = errors.New("moves of a timed game can't be taken back")
 )
 
 // Player represents a tic-tac-toe player.
@@ -10,14 +25,34 @@ type Player interface {
 	Name() string
 }
 
+// Move represents a played move.
+type Move struct {
+	Cell      board.Cell
+	CellValue board.CellValue
+	Player    Player
+	PlayedAt  time.Time
+}
+
 // Game represents a tic-tac-toe game.
-// Game is responsible for managing the game state and
-// the matching between players and board's cell values.
+// Game is responsible for managing the game state,
+// the history of moves and the matching between players and board's cell values.
+// Moves are checked and applied by the rules of the game.
 type Game struct {
+	rules             rules.Rules
 	board             board.Board
+	initialBoard      board.Board
+	moves             []Move
+	undoneMoves       []Move
 	player1           Player
 	player2           Player
 	cellValuesPlayers map[board.CellValue]Player
+	now               func() time.Time
+	// clock is the clock of a timed game, nil means the game is not timed.
+	clock *clock.Clock
+	// flagged is the side whose flag has fallen, it has lost the game on time.
+	flagged board.CellValue
+	// resigned is the side that has resigned, it has lost the game.
+	resigned board.CellValue
 }
 
 // New creates a new game with the given players.
@@ -25,26 +60,231 @@ type Game struct {
 // The first player always starts the game.
 func New(player1, player2 Player) *Game {
 	g := &Game{
+		rules:   rules.Classic{},
 		player1: player1,
 		player2: player2,
 		cellValuesPlayers: map[board.CellValue]Player{
 			board.XValue: player1,
 			board.OValue: player2,
 		},
+		now: time.Now,
 	}
 	return g
 }
 
-// Play plays the given cell.
+// NewWithBoard creates a new game with the given players on the given board.
+// It allows to play on boards of any size and win length, the game is played by the rules of the board variant.
+func NewWithBoard(b board.Board, player1, player2 Player) *Game {
+	g := New(player1, player2)
+	g.board = b
+	g.initialBoard = b
+	g.rules = rules.For(b.Variant())
+	return g
+}
+
+// NewWithRules creates a new game with the given players on the given board played by the rules.
+func NewWithRules(r rules.Rules, b board.Board, player1, player2 Player) *Game {
+	g := NewWithBoard(b, player1, player2)
+	g.rules = r
+	return g
+}
+
+// Play plays the given cell with the mark of the current turn player.
+// Playing a move discards the undone moves.
 func (g *Game) Play(cell board.Cell) error {
-	b, err := g.board.SetCellValue(cell)
+	return g.PlayMove(rules.MoveOf(g.rules, g.board, cell))
+}
+
+// PlayMove plays the move, the rules decide whether its mark is allowed.
+// Playing a move discards the undone moves.
+// In a timed game the move ends the turn on the clock, it is rejected if the flag of the player has fallen.
+func (g *Game) PlayMove(m rules.Move) error {
+	if g.CheckFlag() {
+		return ErrTimeIsUp
+	}
+	err := g.play(Move{
+		Cell:      m.Cell,
+		CellValue: m.Mark,
+		Player:    g.CurrentTurnPlayer(),
+		PlayedAt:  g.now(),
+	})
+	if err != nil {
+		return err
+	}
+	g.undoneMoves = nil
+	g.pressClock()
+	return nil
+}
+
+// StartClock makes the game timed: the clock of the current turn player starts running.
+func (g *Game) StartClock(tc clock.TimeControl) error {
+	c, err := clock.New(tc, g.rules.SideToMove(g.board), g.now())
 	if err != nil {
 		return err
 	}
+	g.clock = c
+	return nil
+}
+
+// Clock returns the clock of the timed game, it returns nil if the game is not timed.
+func (g *Game) Clock() *clock.Clock {
+	return g.clock
+}
+
+// CheckFlag checks the clock and returns true if the flag of the current turn player has fallen,
+// the player loses the game then.
+func (g *Game) CheckFlag() bool {
+	if g.flagged != board.EmptyValue {
+		return true
+	}
+	if g.clock == nil || g.IsOver() {
+		return false
+	}
+	side, ok := g.clock.Flagged(g.now())
+	if !ok {
+		return false
+	}
+	g.flagged = side
+	g.clock.Stop(g.now())
+	return true
+}
+
+// Flagged returns the side whose flag has fallen, it returns false if no flag has fallen.
+func (g *Game) Flagged() (board.CellValue, bool) {
+	return g.flagged, g.flagged != board.EmptyValue
+}
+
+// Flag ends the game on time: the flag of the side has fallen, e.g. in a game replayed from its record.
+// It returns board.ErrGameIsOver if the game is already over.
+func (g *Game) Flag(side board.CellValue) error {
+	if err := g.checkCanForfeit(side); err != nil {
+		return err
+	}
+	g.flagged = side
+	if g.clock != nil {
+		g.clock.Stop(g.now())
+	}
+	return nil
+}
+
+// Resign ends the game, the side gives it up and loses it whoever turn it is.
+// It returns board.ErrGameIsOver if the game is already over.
+func (g *Game) Resign(side board.CellValue) error {
+	if err := g.checkCanForfeit(side); err != nil {
+		return err
+	}
+	g.resigned = side
+	if g.clock != nil {
+		g.clock.Stop(g.now())
+	}
+	return nil
+}
+
+// Resigned returns the side that has resigned, it returns false if no one has.
+func (g *Game) Resigned() (board.CellValue, bool) {
+	return g.resigned, g.resigned != board.EmptyValue
+}
+
+// checkCanForfeit returns an error if the side can't lose the game before its end.
+func (g *Game) checkCanForfeit(side board.CellValue) error {
+	if side != board.XValue && side != board.OValue {
+		return board.ErrInvalidCellValue
+	}
+	if g.CheckFlag() || g.IsOver() {
+		return board.ErrGameIsOver
+	}
+	return nil
+}
+
+// pressClock ends the turn on the clock of the timed game, the clock is stopped when the game is over.
+func (g *Game) pressClock() {
+	if g.clock == nil {
+		return
+	}
+	if g.IsOver() {
+		g.clock.Stop(g.now())
+		return
+	}
+	g.clock.Press(g.now())
+}
+
+// PlayColumn plays the lowest empty cell of the column, it is how moves are made in the gravity variant.
+func (g *Game) PlayColumn(column int) error {
+	cell, err := g.board.DropCell(column)
+	if err != nil {
+		return err
+	}
+	return g.Play(cell)
+}
+
+// play applies the move to the board and appends it to the history.
+func (g *Game) play(m Move) error {
+	if g.resigned != board.EmptyValue {
+		return board.ErrGameIsOver
+	}
+	b, err := g.rules.Apply(g.board, rules.Move{Cell: m.Cell, Mark: m.CellValue})
+	if err != nil {
+		return err
+	}
+	g.board = b
+	g.moves = append(g.moves, m)
+	return nil
+}
+
+// Undo takes back the last move.
+// Moves of a timed game can't be taken back.
+func (g *Game) Undo() error {
+	if g.resigned != board.EmptyValue {
+		return board.ErrGameIsOver
+	}
+	if g.clock != nil {
+		return ErrTimedGame
+	}
+	if len(g.moves) == 0 {
+		return ErrNothingToUndo
+	}
+	last := g.moves[len(g.moves)-1]
+	g.moves = g.moves[:len(g.moves)-1]
+	g.undoneMoves = append(g.undoneMoves, last)
+	// Board is immutable and has no way to clear a cell, so the position is replayed.
+	b := g.initialBoard
+	for _, m := range g.moves {
+		b = rules.MustApply(g.rules, b, rules.Move{Cell: m.Cell, Mark: m.CellValue})
+	}
 	g.board = b
 	return nil
 }
 
+// Redo plays again the last undone move.
+func (g *Game) Redo() error {
+	if len(g.undoneMoves) == 0 {
+		return ErrNothingToRedo
+	}
+	last := g.undoneMoves[len(g.undoneMoves)-1]
+	if err := g.play(last); err != nil {
+		return err
+	}
+	g.undoneMoves = g.undoneMoves[:len(g.undoneMoves)-1]
+	return nil
+}
+
+// CanUndo returns true if there is a move to undo.
+func (g *Game) CanUndo() bool {
+	return g.clock == nil && len(g.moves) > 0
+}
+
+// CanRedo returns true if there is an undone move to redo.
+func (g *Game) CanRedo() bool {
+	return len(g.undoneMoves) > 0
+}
+
+// Moves returns the played moves in order.
+func (g *Game) Moves() []Move {
+	moves := make([]Move, len(g.moves))
+	copy(moves, g.moves)
+	return moves
+}
+
 // MustPlay is like Play but panics if the cell is invalid.
 func (g *Game) MustPlay(cell board.Cell) {
 	err := g.Play(cell)
@@ -53,10 +293,28 @@ func (g *Game) MustPlay(cell board.Cell) {
 	}
 }
 
+// MustPlayMove is like PlayMove but panics if the move is illegal.
+func (g *Game) MustPlayMove(m rules.Move) {
+	if err := g.PlayMove(m); err != nil {
+		panic(err)
+	}
+}
+
 // IsOver returns true if the game is over.
-// The game is over when the board is full or there is a winner.
+// The game is over when the board is full or there is a winner, a timed game is also over when a flag has fallen.
+// A game is also over when a player has resigned.
 func (g *Game) IsOver() bool {
-	return g.board.IsCompleted()
+	return g.flagged != board.EmptyValue || g.resigned != board.EmptyValue || g.rules.IsTerminal(g.board)
+}
+
+// Rules returns the rules of the game.
+func (g *Game) Rules() rules.Rules {
+	return g.rules
+}
+
+// InitialBoard returns the board the game has started on, replaying the moves on it gives the current board.
+func (g *Game) InitialBoard() board.Board {
+	return g.initialBoard
 }
 
 // GetBoard returns the board.
@@ -64,9 +322,20 @@ func (g *Game) GetBoard() board.Board {
 	return g.board
 }
 
+// Players returns the players, the first player is X and the second player is O.
+func (g *Game) Players() (player1, player2 Player) {
+	return g.player1, g.player2
+}
+
 // Winner returns the winner.
 func (g *Game) Winner() Player {
-	if winnCellValue, exist := g.board.Winner(); exist {
+	if g.flagged != board.EmptyValue {
+		return g.cellValuesPlayers[-g.flagged]
+	}
+	if g.resigned != board.EmptyValue {
+		return g.cellValuesPlayers[-g.resigned]
+	}
+	if winnCellValue, exist := g.rules.Result(g.board); exist {
 		p := g.cellValuesPlayers[winnCellValue]
 		return p
 	}
@@ -75,11 +344,16 @@ func (g *Game) Winner() Player {
 
 // CurrentTurnPlayer returns the current turn player.
 func (g *Game) CurrentTurnPlayer() Player {
-	return g.cellValuesPlayers[g.board.CurrentTurnCellValue()]
+	return g.cellValuesPlayers[g.rules.SideToMove(g.board)]
 }
 
 // Sprint returns the string representation of the current state of a game.
 func (g *Game) Sprint(cursor *board.Cell) string {
+	return g.SprintWithHint(cursor, nil)
+}
+
+// SprintWithHint returns the string representation of the current state of a game with the hinted cell.
+func (g *Game) SprintWithHint(cursor, hint *board.Cell) string {
 	if g.IsOver() {
 		s := g.GetBoard().String() + "\n"
 
@@ -87,10 +361,16 @@ func (g *Game) Sprint(cursor *board.Cell) string {
 		if w == nil {
 			return s + "\nGame is over, Draw"
 		}
+		if side, ok := g.Flagged(); ok {
+			return s + fmt.Sprintf("\nGame is over, time is up for %s, winner is: %s\n", g.cellValuesPlayers[side].Name(), w)
+		}
+		if side, ok := g.Resigned(); ok {
+			return s + fmt.Sprintf("\nGame is over, %s has resigned, winner is: %s\n", g.cellValuesPlayers[side].Name(), w)
+		}
 		return s + fmt.Sprintf("\nGame is over, winner is: %s\n", w)
 	}
 
-	result := g.GetBoard().Sprint(cursor) + "\n"
+	result := g.GetBoard().SprintWithHint(cursor, hint) + "\n"
 	result += fmt.Sprintf("Current player: %s", g.CurrentTurnPlayer().Name())
 	return result
 }
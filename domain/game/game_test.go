@@ -4,7 +4,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"testing"
 	"tictactoe/domain/board"
+	"tictactoe/domain/clock"
 	"tictactoe/domain/player"
+	"tictactoe/domain/rules"
+	"time"
 )
 
 func TestGame_Play(t *testing.T) {
@@ -16,21 +19,21 @@ func TestGame_Play(t *testing.T) {
 	}{
 		{
 			name: "when cell is not empty or board is completed  should return error",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-			},
+			}),
 			cell:    board.MustNewCell(0, 0),
 			wantErr: true,
 		},
 		{
 			name: "when cell is empty and board is not completed should update Board and set X value for the cell",
-			board: board.Board{
+			board: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.OValue, board.XValue},
 				{board.EmptyValue, board.XValue, board.EmptyValue},
 				{board.OValue, board.XValue, board.OValue},
-			},
+			}),
 			cell:    board.MustNewCell(0, 0),
 			wantErr: false,
 		},
@@ -58,19 +61,19 @@ func TestGame_IsOver(t *testing.T) {
 	}{
 		{
 			name: "when board is not completed should return false",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 				{board.EmptyValue, board.EmptyValue, board.XValue},
 				{board.EmptyValue, board.EmptyValue, board.OValue},
-			},
+			}),
 		},
 		{
 			name: "when board is completed should return true",
-			b: board.Board{
+			b: board.MustNewFromRows([][]board.CellValue{
 				{board.XValue, board.OValue, board.XValue},
 				{board.XValue, board.XValue, board.OValue},
 				{board.OValue, board.XValue, board.OValue},
-			},
+			}),
 			want: true,
 		},
 	}
@@ -105,11 +108,11 @@ func TestGame_CurrentTurnPlayer(t *testing.T) {
 		p1 := player.MustNew("John")
 		p2 := player.MustNew("Jane")
 		g := New(p1, p2)
-		g.board = board.Board{
+		g.board = board.MustNewFromRows([][]board.CellValue{
 			{board.XValue, board.EmptyValue, board.EmptyValue},
 			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-		}
+		})
 		require.Equal(t, p2, g.CurrentTurnPlayer())
 	})
 }
@@ -121,21 +124,21 @@ func TestGame_Winner(t *testing.T) {
 	})
 	t.Run("when game is over in draw should return nil", func(t *testing.T) {
 		g := New(player.MustNew("John"), player.MustNew("Jane"))
-		g.board = board.Board{
+		g.board = board.MustNewFromRows([][]board.CellValue{
 			{board.XValue, board.OValue, board.XValue},
 			{board.XValue, board.XValue, board.OValue},
 			{board.OValue, board.XValue, board.OValue},
-		}
+		})
 		require.Nil(t, g.Winner())
 	})
 	t.Run("when game is over with winner should return winner", func(t *testing.T) {
 		p1 := player.MustNew("John")
 		g := New(p1, player.MustNew("Jane"))
-		g.board = board.Board{
+		g.board = board.MustNewFromRows([][]board.CellValue{
 			{board.XValue, board.OValue, board.XValue},
 			{board.XValue, board.OValue, board.OValue},
 			{board.XValue, board.XValue, board.OValue},
-		}
+		})
 		require.Equal(t, p1, g.Winner())
 	})
 }
@@ -143,56 +146,289 @@ func TestGame_Winner(t *testing.T) {
 func TestGame_MustPlay(t *testing.T) {
 	t.Run("when cell is not empty or board is completed  should panic", func(t *testing.T) {
 		g := New(player.MustNew("John"), player.MustNew("Jane"))
-		g.board = board.Board{
+		g.board = board.MustNewFromRows([][]board.CellValue{
 			{board.XValue, board.EmptyValue, board.EmptyValue},
 			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
 			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
-		}
+		})
 		require.Panics(t, func() {
 			g.MustPlay(board.MustNewCell(0, 0))
 		})
 	})
 	t.Run("when cell is empty and board is not completed should update Board and set X value for the cell", func(t *testing.T) {
 		g := New(player.MustNew("John"), player.MustNew("Jane"))
-		g.board = board.Board{
+		g.board = board.MustNewFromRows([][]board.CellValue{
 			{board.EmptyValue, board.OValue, board.XValue},
 			{board.EmptyValue, board.XValue, board.EmptyValue},
 			{board.OValue, board.XValue, board.OValue},
-		}
+		})
 		g.MustPlay(board.MustNewCell(0, 0))
 		require.Equal(t, board.XValue, g.board.CellValue(board.MustNewCell(0, 0)))
 	})
 }
 
+func TestGame_MustPlayMove(t *testing.T) {
+	t.Run("when the mark is not allowed by the rules should panic", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		require.Panics(t, func() {
+			g.MustPlayMove(rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.OValue})
+		})
+	})
+	t.Run("when the variant is wild should place the mark of the move", func(t *testing.T) {
+		g := NewWithBoard(board.Board{}.WithVariant(board.Wild), player.MustNew("John"), player.MustNew("Jane"))
+		g.MustPlayMove(rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.OValue})
+		require.Equal(t, board.OValue, g.GetBoard().CellValue(board.MustNewCell(0, 0)))
+	})
+}
+
 func TestGame_Sprint(t *testing.T) {
 	t.Run("when cursor is nil should return string representation of the current state of a game", func(t *testing.T) {
 		g := New(player.MustNew("John"), player.MustNew("Jane"))
-		g.board = board.Board{
+		g.board = board.MustNewFromRows([][]board.CellValue{
 			{board.EmptyValue, board.OValue, board.XValue},
 			{board.EmptyValue, board.XValue, board.EmptyValue},
 			{board.OValue, board.XValue, board.OValue},
-		}
+		})
 		want := " -  |  O  |  X \n -  |  X  |  - \n O  |  X  |  O \n\nCurrent player: John"
 		require.Equal(t, want, g.Sprint(nil))
 	})
 	t.Run("when game is over with draw should return string representation of the current state of a game", func(t *testing.T) {
 		g := New(player.MustNew("John"), player.MustNew("Jane"))
-		g.board = board.Board{
+		g.board = board.MustNewFromRows([][]board.CellValue{
 			{board.XValue, board.OValue, board.XValue},
 			{board.OValue, board.XValue, board.XValue},
 			{board.OValue, board.XValue, board.OValue},
-		}
+		})
 		want := " X  |  O  |  X \n O  |  X  |  X \n O  |  X  |  O \n\n\nGame is over, Draw"
 		require.Equal(t, want, g.Sprint(nil))
 	})
 	t.Run("when game is over with a winner should return string representation of the current state of a game", func(t *testing.T) {
 		g := New(player.MustNew("John"), player.MustNew("Jane"))
-		g.board = board.Board{
+		g.board = board.MustNewFromRows([][]board.CellValue{
 			{board.XValue, board.OValue, board.XValue},
 			{board.OValue, board.OValue, board.XValue},
 			{board.OValue, board.XValue, board.XValue},
-		}
+		})
 		want := " X  |  O  |  X \n O  |  O  |  X \n O  |  X  |  X \n\n\nGame is over, winner is: John\n"
 		require.Equal(t, want, g.Sprint(nil))
 	})
 }
+
+func TestNewWithBoard(t *testing.T) {
+	p1 := player.MustNew("John")
+	p2 := player.MustNew("Jane")
+	b := board.MustNew(5, 4)
+	g := NewWithBoard(b, p1, p2)
+	require.Equal(t, b, g.GetBoard())
+	require.Equal(t, p1, g.CurrentTurnPlayer())
+	g.MustPlay(board.MustNewCell(0, 0))
+	require.Equal(t, b, g.InitialBoard())
+}
+
+// firstMoveWins are the rules where the first move wins the game.
+type firstMoveWins struct {
+	rules.Classic
+}
+
+func (firstMoveWins) IsTerminal(b board.Board) bool {
+	return b.FullCellsCount() > 0
+}
+
+func (firstMoveWins) Result(b board.Board) (board.CellValue, bool) {
+	return board.XValue, b.FullCellsCount() > 0
+}
+
+func TestNewWithRules(t *testing.T) {
+	p1 := player.MustNew("John")
+	p2 := player.MustNew("Jane")
+	g := NewWithRules(firstMoveWins{}, board.Board{}, p1, p2)
+	require.Equal(t, firstMoveWins{}, g.Rules())
+	require.False(t, g.IsOver())
+	require.ErrorIs(t, g.PlayMove(rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.OValue}), rules.ErrWrongMark)
+	require.NoError(t, g.PlayMove(rules.Move{Cell: board.MustNewCell(0, 0), Mark: board.XValue}))
+	require.True(t, g.IsOver())
+	require.Equal(t, p1, g.Winner())
+	require.NoError(t, g.Undo())
+	require.False(t, g.IsOver())
+}
+
+func TestGame_PlayColumn(t *testing.T) {
+	g := NewWithBoard(board.MustNewRectangular(3, 4, 3).WithVariant(board.Gravity), player.MustNew("John"), player.MustNew("Jane"))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, g.PlayColumn(1))
+	}
+	require.ErrorIs(t, g.PlayColumn(1), board.ErrColumnIsFull)
+	require.ErrorIs(t, g.PlayColumn(4), board.ErrInvalidCell)
+	require.Equal(t, board.XValue, g.GetBoard().CellValue(board.MustNewCell(2, 1)))
+	require.Equal(t, board.OValue, g.GetBoard().CellValue(board.MustNewCell(1, 1)))
+	require.Len(t, g.Moves(), 3)
+}
+
+func TestGame_Moves(t *testing.T) {
+	p1 := player.MustNew("John")
+	p2 := player.MustNew("Jane")
+	g := New(p1, p2)
+	playedAt := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return playedAt }
+	g.MustPlay(board.MustNewCell(1, 1))
+	g.MustPlay(board.MustNewCell(0, 0))
+	require.Error(t, g.Play(board.MustNewCell(0, 0)))
+	require.Equal(t, []Move{
+		{Cell: board.MustNewCell(1, 1), CellValue: board.XValue, Player: p1, PlayedAt: playedAt},
+		{Cell: board.MustNewCell(0, 0), CellValue: board.OValue, Player: p2, PlayedAt: playedAt},
+	}, g.Moves())
+}
+
+func TestGame_UndoRedo(t *testing.T) {
+	t.Run("when there are no moves should return errors", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		require.False(t, g.CanUndo())
+		require.False(t, g.CanRedo())
+		require.Equal(t, ErrNothingToUndo, g.Undo())
+		require.Equal(t, ErrNothingToRedo, g.Redo())
+	})
+	t.Run("should take back moves and play them again", func(t *testing.T) {
+		p1 := player.MustNew("John")
+		g := New(p1, player.MustNew("Jane"))
+		g.MustPlay(board.MustNewCell(1, 1))
+		afterFirstMove := g.GetBoard()
+		g.MustPlay(board.MustNewCell(0, 0))
+		afterSecondMove := g.GetBoard()
+
+		require.NoError(t, g.Undo())
+		require.Equal(t, afterFirstMove, g.GetBoard())
+		require.NoError(t, g.Undo())
+		require.Equal(t, board.Board{}, g.GetBoard())
+		require.Equal(t, p1, g.CurrentTurnPlayer())
+		require.Empty(t, g.Moves())
+		require.True(t, g.CanRedo())
+
+		require.NoError(t, g.Redo())
+		require.NoError(t, g.Redo())
+		require.Equal(t, afterSecondMove, g.GetBoard())
+		require.Len(t, g.Moves(), 2)
+		require.Equal(t, ErrNothingToRedo, g.Redo())
+	})
+	t.Run("when a move is played after undo should discard undone moves", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		g.MustPlay(board.MustNewCell(1, 1))
+		require.NoError(t, g.Undo())
+		g.MustPlay(board.MustNewCell(0, 0))
+		require.False(t, g.CanRedo())
+		require.Equal(t, ErrNothingToRedo, g.Redo())
+	})
+	t.Run("when game is over should take back the winning move", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		for _, c := range [][]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}} {
+			g.MustPlay(board.MustNewCell(c[0], c[1]))
+		}
+		require.True(t, g.IsOver())
+		require.NoError(t, g.Undo())
+		require.False(t, g.IsOver())
+	})
+	t.Run("when game is started with a board should undo to this board", func(t *testing.T) {
+		b := board.MustNew(4, 3)
+		g := NewWithBoard(b, player.MustNew("John"), player.MustNew("Jane"))
+		g.MustPlay(board.MustNewCell(3, 3))
+		require.NoError(t, g.Undo())
+		require.Equal(t, b, g.GetBoard())
+	})
+}
+
+func TestGame_Clock(t *testing.T) {
+	start := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	newTimedGame := func(t *testing.T, now *time.Time) *Game {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		g.now = func() time.Time { return *now }
+		require.NoError(t, g.StartClock(clock.Fischer(time.Minute, time.Second)))
+		return g
+	}
+	t.Run("when moves are played in time should run the clock of the current turn player", func(t *testing.T) {
+		now := start
+		g := newTimedGame(t, &now)
+		now = now.Add(10 * time.Second)
+		g.MustPlay(board.MustNewCell(1, 1))
+		require.Equal(t, board.OValue, g.Clock().Side())
+		require.Equal(t, 51*time.Second, g.Clock().Remaining(board.XValue, now))
+		require.False(t, g.CheckFlag())
+		require.ErrorIs(t, g.Undo(), ErrTimedGame)
+		require.False(t, g.CanUndo())
+	})
+	t.Run("when the flag of the current turn player falls should lose the game", func(t *testing.T) {
+		now := start
+		g := newTimedGame(t, &now)
+		g.MustPlay(board.MustNewCell(1, 1))
+		now = now.Add(time.Minute)
+		require.ErrorIs(t, g.Play(board.MustNewCell(0, 0)), ErrTimeIsUp)
+		require.True(t, g.IsOver())
+		side, ok := g.Flagged()
+		require.True(t, ok)
+		require.Equal(t, board.OValue, side)
+		require.Equal(t, player.MustNew("John"), g.Winner())
+		require.Contains(t, g.Sprint(nil), "time is up for Jane, winner is: John")
+	})
+	t.Run("when the game is over on the board should stop the clock", func(t *testing.T) {
+		now := start
+		g := newTimedGame(t, &now)
+		for _, c := range []board.Cell{
+			board.MustNewCell(0, 0), board.MustNewCell(1, 0),
+			board.MustNewCell(0, 1), board.MustNewCell(1, 1),
+			board.MustNewCell(0, 2),
+		} {
+			g.MustPlay(c)
+		}
+		now = now.Add(time.Hour)
+		require.False(t, g.CheckFlag())
+		require.Equal(t, player.MustNew("John"), g.Winner())
+	})
+	t.Run("when the time control is invalid should return error", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		require.ErrorIs(t, g.StartClock(clock.TimeControl{}), clock.ErrInvalidTimeControl)
+		require.Nil(t, g.Clock())
+	})
+}
+
+func TestGame_Resign(t *testing.T) {
+	t.Run("when a player resigns should lose the game", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		g.MustPlay(board.MustNewCell(1, 1))
+		require.NoError(t, g.Resign(board.XValue))
+		require.True(t, g.IsOver())
+		side, ok := g.Resigned()
+		require.True(t, ok)
+		require.Equal(t, board.XValue, side)
+		require.Equal(t, player.MustNew("Jane"), g.Winner())
+		require.Contains(t, g.Sprint(nil), "John has resigned, winner is: Jane")
+		require.ErrorIs(t, g.Play(board.MustNewCell(0, 0)), board.ErrGameIsOver)
+		require.ErrorIs(t, g.Undo(), board.ErrGameIsOver)
+	})
+	t.Run("when the game is over should return error", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		require.NoError(t, g.Resign(board.OValue))
+		require.ErrorIs(t, g.Resign(board.XValue), board.ErrGameIsOver)
+		require.ErrorIs(t, g.Flag(board.XValue), board.ErrGameIsOver)
+	})
+	t.Run("when the side is not a mark should return error", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		require.ErrorIs(t, g.Resign(board.EmptyValue), board.ErrInvalidCellValue)
+	})
+}
+
+func TestGame_Flag(t *testing.T) {
+	t.Run("when the flag falls should lose the game on time", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		require.NoError(t, g.Flag(board.OValue))
+		require.True(t, g.IsOver())
+		require.Equal(t, player.MustNew("John"), g.Winner())
+		require.ErrorIs(t, g.Play(board.MustNewCell(0, 0)), ErrTimeIsUp)
+	})
+	t.Run("when the game is over on the board should return error", func(t *testing.T) {
+		g := New(player.MustNew("John"), player.MustNew("Jane"))
+		g.board = board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, board.XValue},
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		require.ErrorIs(t, g.Flag(board.XValue), board.ErrGameIsOver)
+	})
+}
@@ -0,0 +1,186 @@
+// Package hint suggests the next move to a human player and explains it in one line.
+package hint
+
+import (
+	"fmt"
+
+	"tictactoe/domain/analysis"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/rules"
+)
+
+// Hint is the suggested cell with the reason to play it.
+type Hint struct {
+	Cell board.Cell
+	// Mark is the mark to place, it is the mark of the side to move unless the variant is wild.
+	Mark   board.CellValue
+	Reason string
+}
+
+// FromStrategy returns the move the strategy would play, explained by ExplainMove.
+func FromStrategy(b board.Board, s computer.Strategy) Hint {
+	m := computer.FindBestMove(s, rules.For(b.Variant()), b)
+	return Hint{Cell: m.Cell, Mark: m.Mark, Reason: ExplainMove(b, m.Cell, m.Mark)}
+}
+
+// FromAnalysis returns the perfect-play cell, the reason also tells the outcome of perfect play.
+// It returns analysis.ErrTooManyEmptyCells if the position is too big to be solved.
+func FromAnalysis(b board.Board) (Hint, error) {
+	a, err := analysis.Analyze(b)
+	if err != nil {
+		return Hint{}, err
+	}
+	best := a.Best()
+	reason := ExplainMove(b, best.Cell, best.Mark)
+	switch best.Outcome {
+	case analysis.Win:
+		if best.Plies > 1 {
+			reason += fmt.Sprintf(", forces a win in %d moves", (best.Plies+1)/2)
+		}
+	case analysis.Draw:
+		reason += ", keeps the draw"
+	case analysis.Loss:
+		reason += ", the opponent can force a win anyway"
+	}
+	return Hint{Cell: best.Cell, Mark: best.Mark, Reason: reason}, nil
+}
+
+// Explain returns the one-line reason to play the empty cell for the side to move, e.g. "blocks O's row 2".
+func Explain(b board.Board, cell board.Cell) string {
+	return ExplainMove(b, cell, b.CurrentTurnCellValue())
+}
+
+// ExplainMove returns the one-line reason to place the mark on the empty cell.
+// The mark matters only in the wild variant, in other variants the side to move places its own mark.
+func ExplainMove(b board.Board, cell board.Cell, mark board.CellValue) string {
+	if b.Variant() == board.Wild {
+		return explainWild(b, cell, mark)
+	}
+	me, opponent := b.CurrentTurnCellValue(), b.OpponentCellValue()
+	if line, ok := completedLine(b, cell, me); ok {
+		return "wins with " + describeLine(line)
+	}
+	if line, ok := completedLine(b, cell, opponent); ok {
+		return fmt.Sprintf("blocks %s's %s", opponent, describeLine(line))
+	}
+	threats := threatCells(b, cell, me)
+	if len(threats) >= 2 {
+		return "creates a fork"
+	}
+	if len(threatCells(b, cell, opponent)) >= 2 {
+		return fmt.Sprintf("blocks %s's fork", opponent)
+	}
+	if len(threats) == 1 {
+		return "threatens to win at " + describeCell(threats[0])
+	}
+	return describePlace(b, cell)
+}
+
+// explainWild explains the move of the wild variant where a line completed with either mark wins,
+// so an empty cell of a line that misses one mark is a win for whoever moves first.
+func explainWild(b board.Board, cell board.Cell, mark board.CellValue) string {
+	if line, ok := completedLine(b, cell, mark); ok {
+		return "wins with " + describeLine(line)
+	}
+	if line, ok := completedLine(b, cell, -mark); ok {
+		return "blocks " + describeLine(line)
+	}
+	return describePlace(b, cell)
+}
+
+// describePlace returns the reason to play the cell by its place on the board.
+func describePlace(b board.Board, cell board.Cell) string {
+	switch {
+	case cell == b.MidCell():
+		return "takes the center"
+	case isCorner(b, cell):
+		return "takes a corner"
+	case isBorder(b, cell):
+		return "takes a side"
+	}
+	return "takes an open cell"
+}
+
+// completedLine returns the line the value completes by playing the cell.
+func completedLine(b board.Board, cell board.Cell, value board.CellValue) ([]board.Cell, bool) {
+	for _, line := range b.Lines() {
+		if contains(line, cell) && count(b, line, value) == len(line)-1 {
+			return line, true
+		}
+	}
+	return nil, false
+}
+
+// threatCells returns the distinct cells the value would win at after playing the cell,
+// a fork is two or more such cells.
+func threatCells(b board.Board, cell board.Cell, value board.CellValue) []board.Cell {
+	var cells []board.Cell
+	for _, line := range b.Lines() {
+		if !contains(line, cell) || count(b, line, value) != len(line)-2 {
+			continue
+		}
+		for _, c := range line {
+			if c != cell && b.IsEmptyCell(c) && !contains(cells, c) {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+// count returns the count of cells of the line with the value,
+// it returns -1 if the line has a cell of the other player, so the line can't be completed.
+func count(b board.Board, line []board.Cell, value board.CellValue) int {
+	n := 0
+	for _, c := range line {
+		switch b.CellValue(c) {
+		case value:
+			n++
+		case board.EmptyValue:
+		default:
+			return -1
+		}
+	}
+	return n
+}
+
+// contains returns true if the cells contain the cell.
+func contains(cells []board.Cell, cell board.Cell) bool {
+	for _, c := range cells {
+		if c == cell {
+			return true
+		}
+	}
+	return false
+}
+
+// describeLine returns the human-readable name of the line, rows and columns are numbered from 1.
+func describeLine(line []board.Cell) string {
+	first, last := line[0], line[len(line)-1]
+	switch {
+	case first.RowNumber == last.RowNumber:
+		return fmt.Sprintf("row %d", first.RowNumber+1)
+	case first.ColumnNumber == last.ColumnNumber:
+		return fmt.Sprintf("column %d", first.ColumnNumber+1)
+	case first.ColumnNumber < last.ColumnNumber:
+		return "diagonal"
+	}
+	return "anti-diagonal"
+}
+
+// describeCell returns the human-readable position of the cell, rows and columns are numbered from 1.
+func describeCell(cell board.Cell) string {
+	return fmt.Sprintf("row %d, column %d", cell.RowNumber+1, cell.ColumnNumber+1)
+}
+
+// isCorner returns true if the cell is a corner of the board.
+func isCorner(b board.Board, cell board.Cell) bool {
+	return contains(b.Corners(), cell)
+}
+
+// isBorder returns true if the cell is on the border of the board.
+func isBorder(b board.Board, cell board.Cell) bool {
+	return cell.RowNumber == 0 || cell.ColumnNumber == 0 ||
+		cell.RowNumber == b.Rows()-1 || cell.ColumnNumber == b.Columns()-1
+}
@@ -0,0 +1,150 @@
+package hint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/analysis"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer/strategies/wiki"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		name string
+		b    board.Board
+		cell board.Cell
+		want string
+	}{
+		{
+			name: "when cell wins should name the line",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.OValue},
+				{board.EmptyValue, board.XValue, board.OValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			cell: board.MustNewCell(2, 2),
+			want: "wins with diagonal",
+		},
+		{
+			name: "when cell blocks should name the opponent line",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.OValue, board.OValue, board.EmptyValue},
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+			}),
+			cell: board.MustNewCell(1, 2),
+			want: "blocks O's row 2",
+		},
+		{
+			name: "when cell creates a threat should name the winning cell",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.OValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.XValue},
+			}),
+			cell: board.MustNewCell(1, 2),
+			want: "threatens to win at row 2, column 1",
+		},
+		{
+			name: "when cell creates a fork should report it",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.OValue, board.OValue},
+				{board.EmptyValue, board.XValue, board.EmptyValue},
+			}),
+			cell: board.MustNewCell(2, 0),
+			want: "creates a fork",
+		},
+		{
+			name: "when opponent can fork at the cell should report the block",
+			b: board.MustNewFromRows([][]board.CellValue{
+				{board.OValue, board.EmptyValue, board.EmptyValue},
+				{board.EmptyValue, board.XValue, board.EmptyValue},
+				{board.EmptyValue, board.XValue, board.OValue},
+			}),
+			cell: board.MustNewCell(0, 2),
+			want: "blocks O's fork",
+		},
+		{
+			name: "when board is empty should take the center",
+			b:    board.Board{},
+			cell: board.MustNewCell(1, 1),
+			want: "takes the center",
+		},
+		{
+			name: "when board is empty should take a corner",
+			b:    board.Board{},
+			cell: board.MustNewCell(2, 0),
+			want: "takes a corner",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Explain(tt.b, tt.cell))
+		})
+	}
+}
+
+func TestExplainMove(t *testing.T) {
+	b, err := board.Board{}.WithVariant(board.Wild).PlaceCellValue(board.MustNewCell(0, 0), board.OValue)
+	require.NoError(t, err)
+	b, err = b.PlaceCellValue(board.MustNewCell(0, 1), board.OValue)
+	require.NoError(t, err)
+	tests := []struct {
+		name string
+		cell board.Cell
+		mark board.CellValue
+		want string
+	}{
+		{name: "when the wild move completes a line with the other mark should win", cell: board.MustNewCell(0, 2), mark: board.OValue, want: "wins with row 1"},
+		{name: "when the wild move spoils a line should block it", cell: board.MustNewCell(0, 2), mark: board.XValue, want: "blocks row 1"},
+		{name: "when the wild move completes no line should name the place", cell: board.MustNewCell(1, 1), mark: board.XValue, want: "takes the center"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, ExplainMove(b, tt.cell, tt.mark))
+		})
+	}
+}
+
+func TestFromStrategy(t *testing.T) {
+	b := board.MustNewFromRows([][]board.CellValue{
+		{board.XValue, board.EmptyValue, board.EmptyValue},
+		{board.OValue, board.OValue, board.EmptyValue},
+		{board.XValue, board.EmptyValue, board.EmptyValue},
+	})
+	require.Equal(t, Hint{Cell: board.MustNewCell(1, 2), Mark: board.XValue, Reason: "blocks O's row 2"}, FromStrategy(b, wiki.NewStrategy()))
+}
+
+func TestFromAnalysis(t *testing.T) {
+	t.Run("when there is a forced win should tell how fast it is", func(t *testing.T) {
+		b := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		h, err := FromAnalysis(b)
+		require.NoError(t, err)
+		require.Equal(t, Hint{Cell: board.MustNewCell(1, 0), Mark: board.XValue, Reason: "threatens to win at row 3, column 1, forces a win in 3 moves"}, h)
+	})
+	t.Run("when position is drawn should tell the draw is kept", func(t *testing.T) {
+		h, err := FromAnalysis(board.Board{})
+		require.NoError(t, err)
+		require.Equal(t, Hint{Cell: board.MustNewCell(0, 0), Mark: board.XValue, Reason: "takes a corner, keeps the draw"}, h)
+	})
+	t.Run("when variant is wild should suggest the mark", func(t *testing.T) {
+		b, err := board.Board{}.WithVariant(board.Wild).PlaceCellValue(board.MustNewCell(0, 0), board.OValue)
+		require.NoError(t, err)
+		b, err = b.PlaceCellValue(board.MustNewCell(0, 1), board.OValue)
+		require.NoError(t, err)
+		h, err := FromAnalysis(b)
+		require.NoError(t, err)
+		require.Equal(t, Hint{Cell: board.MustNewCell(0, 2), Mark: board.OValue, Reason: "wins with row 1"}, h)
+	})
+	t.Run("when position is too big should return error", func(t *testing.T) {
+		_, err := FromAnalysis(board.MustNew(7, 4))
+		require.ErrorIs(t, err, analysis.ErrTooManyEmptyCells)
+	})
+}
@@ -0,0 +1,472 @@
+// Package httpapi implements the HTTP/JSON API of games.
+//
+// The API has the following endpoints:
+//
+//	POST   /games             creates a game, computer players play their moves right away
+//	GET    /games             lists the games
+//	GET    /games/{id}        returns the game
+//	POST   /games/{id}/moves  plays the cell of the current human player, the computer replies right away
+//	DELETE /games/{id}        deletes the game
+//	GET    /strategies        lists the registered computer strategies
+//
+// Computer players are rejected with the code unsupported_board on the boards their strategies don't play,
+// and every computer move is searched within the move timeout.
+//
+// Games and moves are written in the record format, cells are written in the record cell notation, e.g. b2.
+// Errors are returned with the matching status code and the body:
+//
+//	{"error": {"code": "cell_is_not_empty", "message": "cell is not empty"}}
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/game"
+	"tictactoe/domain/player"
+	"tictactoe/domain/record"
+)
+
+var (
+	// ErrGameNotFound is returned when there is no game with the id.
+	ErrGameNotFound = errors.New("game not found")
+	// ErrNotHumanTurn is returned when a move is posted while it is a computer turn.
+	ErrNotHumanTurn = errors.New("it is not a human player turn")
+	// ErrInvalidBody is returned when the request body can't be decoded.
+	ErrInvalidBody = errors.New("invalid request body")
+	// ErrMethodNotAllowed is returned when the endpoint doesn't support the method.
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	// ErrRouteNotFound is returned when there is no endpoint at the path.
+	ErrRouteNotFound = errors.New("route not found")
+)
+
+const (
+	// maxBodySize limits the size of request bodies.
+	maxBodySize = 1 << 16
+	// defaultMoveTimeout bounds the search of every computer move.
+	defaultMoveTimeout = 2 * time.Second
+)
+
+// CreateGameRequest is the body of the create game request.
+// A player with a strategy is a computer player, the strategy is found by the handler lookup.
+type CreateGameRequest struct {
+	BoardSize int           `json:"board_size"`
+	WinLength int           `json:"win_length"`
+	PlayerX   record.Player `json:"player_x"`
+	PlayerO   record.Player `json:"player_o"`
+}
+
+// MoveRequest is the body of the move request.
+type MoveRequest struct {
+	Cell string `json:"cell"`
+}
+
+// Game is the game state returned by the API.
+type Game struct {
+	ID string `json:"id"`
+	record.Record
+	// Board contains the rows of the board, e.g. "X-O".
+	Board []string `json:"board"`
+	// Turn is the mark of the current turn player, it is empty when the game is over.
+	Turn string `json:"turn,omitempty"`
+}
+
+// Strategy describes a registered computer strategy.
+type Strategy struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// StrategyList is the body of the list strategies response.
+type StrategyList struct {
+	Strategies []Strategy `json:"strategies"`
+}
+
+// GameList is the body of the list games response.
+type GameList struct {
+	Games []Game `json:"games"`
+}
+
+// Error is the body of an error response.
+type Error struct {
+	Error ErrorDetails `json:"error"`
+}
+
+// ErrorDetails describes the error, the code is stable and the message is human-readable.
+type ErrorDetails struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Handler serves the API and keeps the games in memory.
+// Handler is safe for concurrent use, moves of the same game are played one at a time.
+// Computer moves are searched without locking the game, so the game can be read during the search.
+type Handler struct {
+	lookup      record.StrategyLookup
+	now         func() time.Time
+	moveTimeout time.Duration
+
+	mu     sync.RWMutex
+	games  map[string]*entry
+	nextID int
+}
+
+// entry is a stored game.
+type entry struct {
+	mu      sync.Mutex
+	id      string
+	created time.Time
+	game    *game.Game
+}
+
+// Option configures the Handler.
+type Option func(*Handler)
+
+// WithMoveTimeout bounds the search of every computer move by time, the default is two seconds.
+// Only strategies that implement computer.DeadlineStrategy respect it,
+// the others play only the boards they search in reasonable time.
+func WithMoveTimeout(timeout time.Duration) Option {
+	return func(h *Handler) {
+		h.moveTimeout = timeout
+	}
+}
+
+// NewHandler creates a new handler, computer strategies are found by the lookup.
+func NewHandler(lookup record.StrategyLookup, opts ...Option) *Handler {
+	h := &Handler{
+		lookup:      lookup,
+		now:         time.Now,
+		moveTimeout: defaultMoveTimeout,
+		games:       map[string]*entry{},
+		nextID:      1,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// ServeHTTP routes the request to the endpoint.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 1 && parts[0] == "strategies" {
+		if r.Method != http.MethodGet {
+			writeError(w, ErrMethodNotAllowed)
+			return
+		}
+		listStrategies(w)
+		return
+	}
+	if parts[0] != "games" {
+		writeError(w, ErrRouteNotFound)
+		return
+	}
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		h.create(w, r)
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		h.list(w)
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		h.get(w, parts[1])
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		h.delete(w, parts[1])
+	case len(parts) == 3 && parts[2] == "moves" && r.Method == http.MethodPost:
+		h.move(w, r, parts[1])
+	case len(parts) <= 2, len(parts) == 3 && parts[2] == "moves":
+		writeError(w, ErrMethodNotAllowed)
+	default:
+		writeError(w, ErrRouteNotFound)
+	}
+}
+
+// create creates the game.
+func (h *Handler) create(w http.ResponseWriter, r *http.Request) {
+	var req CreateGameRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if req.BoardSize == 0 {
+		req.BoardSize = board.DefaultSize
+	}
+	if req.WinLength == 0 {
+		req.WinLength = req.BoardSize
+	}
+	b, err := board.New(req.BoardSize, req.WinLength)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	player1, err := h.gamePlayer(req.PlayerX, b)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	player2, err := h.gamePlayer(req.PlayerO, b)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	e := &entry{created: h.now().UTC().Truncate(time.Second), game: game.NewWithBoard(b, player1, player2)}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := h.playComputerTurns(e); err != nil {
+		writeError(w, err)
+		return
+	}
+	h.mu.Lock()
+	e.id = strconv.Itoa(h.nextID)
+	h.nextID++
+	h.games[e.id] = e
+	h.mu.Unlock()
+	writeJSON(w, http.StatusCreated, e.view())
+}
+
+// gamePlayer creates the game player of the requested player on the board.
+// Computer strategies that don't play the board are rejected, so a request can't hang or crash the server.
+func (h *Handler) gamePlayer(p record.Player, b board.Board) (game.Player, error) {
+	if p.Strategy == "" {
+		return player.New(p.Name)
+	}
+	s, err := h.lookup(p.Strategy)
+	if err != nil {
+		return nil, &strategyError{err: err}
+	}
+	if err := computer.CheckBoard(p.Strategy, b); err != nil {
+		return nil, err
+	}
+	return computer.New(s), nil
+}
+
+// list returns all games ordered by id.
+func (h *Handler) list(w http.ResponseWriter) {
+	h.mu.RLock()
+	entries := make([]*entry, 0, len(h.games))
+	for _, e := range h.games {
+		entries = append(entries, e)
+	}
+	h.mu.RUnlock()
+	sort.Slice(entries, func(i, j int) bool {
+		a, _ := strconv.Atoi(entries[i].id)
+		b, _ := strconv.Atoi(entries[j].id)
+		return a < b
+	})
+
+	list := GameList{Games: make([]Game, 0, len(entries))}
+	for _, e := range entries {
+		e.mu.Lock()
+		list.Games = append(list.Games, e.view())
+		e.mu.Unlock()
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// listStrategies returns the registered computer strategies.
+func listStrategies(w http.ResponseWriter) {
+	list := StrategyList{Strategies: []Strategy{}}
+	for _, info := range computer.Strategies() {
+		list.Strategies = append(list.Strategies, Strategy{ID: info.ID, Name: info.Name, Description: info.Description})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// get returns the game.
+func (h *Handler) get(w http.ResponseWriter, id string) {
+	e, err := h.find(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	writeJSON(w, http.StatusOK, e.view())
+}
+
+// delete deletes the game.
+func (h *Handler) delete(w http.ResponseWriter, id string) {
+	h.mu.Lock()
+	_, ok := h.games[id]
+	delete(h.games, id)
+	h.mu.Unlock()
+	if !ok {
+		writeError(w, ErrGameNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// move plays the cell of the current human player and the computer replies.
+func (h *Handler) move(w http.ResponseWriter, r *http.Request, id string) {
+	e, err := h.find(id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req MoveRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	cell, err := record.ParseCell(req.Cell)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.game.CurrentTurnPlayer().(computer.Player); ok && !e.game.IsOver() {
+		writeError(w, ErrNotHumanTurn)
+		return
+	}
+	if err := e.game.Play(cell); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := h.playComputerTurns(e); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, e.view())
+}
+
+// find returns the game with the id.
+func (h *Handler) find(id string) (*entry, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	e, ok := h.games[id]
+	if !ok {
+		return nil, ErrGameNotFound
+	}
+	return e, nil
+}
+
+// playComputerTurns plays while it is a computer turn, every move is searched within the move timeout.
+// The entry must be locked, it is unlocked during the search of every move, which is done on a snapshot of the board.
+// Human moves are rejected meanwhile as it is a computer turn, so the move is played only on the unchanged game.
+func (h *Handler) playComputerTurns(e *entry) error {
+	for !e.game.IsOver() {
+		p, ok := e.game.CurrentTurnPlayer().(computer.Player)
+		if !ok {
+			return nil
+		}
+		p = p.WithRules(e.game.Rules()).WithDeadline(time.Now().Add(h.moveTimeout))
+		b, played := e.game.GetBoard(), len(e.game.Moves())
+		e.mu.Unlock()
+		m := p.GetNextMove(b)
+		e.mu.Lock()
+		if len(e.game.Moves()) != played {
+			return nil
+		}
+		if err := e.game.PlayMove(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// view returns the API representation of the game, the entry must be locked.
+func (e *entry) view() Game {
+	b := e.game.GetBoard()
+	v := Game{
+		ID:     e.id,
+		Record: record.FromGame(e.game, e.created),
+		Board:  make([]string, b.Rows()),
+	}
+	for i := range v.Board {
+		var row strings.Builder
+		for j := 0; j < b.Columns(); j++ {
+			row.WriteString(b.CellValue(board.Cell{RowNumber: i, ColumnNumber: j}).String())
+		}
+		v.Board[i] = row.String()
+	}
+	if !e.game.IsOver() {
+		v.Turn = b.CurrentTurnCellValue().String()
+	}
+	return v
+}
+
+// strategyError wraps the error of the strategy lookup.
+type strategyError struct {
+	err error
+}
+
+func (e *strategyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *strategyError) Unwrap() error {
+	return e.err
+}
+
+// decodeBody decodes the JSON request body.
+func decodeBody(r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return errors.Join(ErrInvalidBody, err)
+	}
+	return nil
+}
+
+// errorStatus returns the status code and the code of the error.
+func errorStatus(err error) (int, string) {
+	var se *strategyError
+	switch {
+	case errors.Is(err, ErrGameNotFound):
+		return http.StatusNotFound, "game_not_found"
+	case errors.Is(err, ErrRouteNotFound):
+		return http.StatusNotFound, "not_found"
+	case errors.Is(err, ErrMethodNotAllowed):
+		return http.StatusMethodNotAllowed, "method_not_allowed"
+	case errors.Is(err, ErrInvalidBody):
+		return http.StatusBadRequest, "invalid_body"
+	case errors.Is(err, computer.ErrUnsupportedBoard):
+		return http.StatusBadRequest, "unsupported_board"
+	case errors.Is(err, board.ErrCellIsNotEmpty):
+		return http.StatusConflict, "cell_is_not_empty"
+	case errors.Is(err, board.ErrGameIsOver):
+		return http.StatusConflict, "game_is_over"
+	case errors.Is(err, ErrNotHumanTurn):
+		return http.StatusConflict, "not_human_turn"
+	case errors.Is(err, board.ErrInvalidCell), errors.Is(err, record.ErrInvalidCellNotation):
+		return http.StatusUnprocessableEntity, "invalid_cell"
+	case errors.Is(err, board.ErrInvalidSize):
+		return http.StatusUnprocessableEntity, "invalid_size"
+	case errors.Is(err, board.ErrInvalidWinLength):
+		return http.StatusUnprocessableEntity, "invalid_win_length"
+	case errors.Is(err, player.ErrInvalidPlayer):
+		return http.StatusUnprocessableEntity, "invalid_player"
+	case errors.As(err, &se), errors.Is(err, computer.ErrUnknownStrategy):
+		return http.StatusUnprocessableEntity, "unknown_strategy"
+	}
+	return http.StatusInternalServerError, "internal_error"
+}
+
+// writeError writes the error response.
+func writeError(w http.ResponseWriter, err error) {
+	status, code := errorStatus(err)
+	message := err.Error()
+	if errors.Is(err, ErrInvalidBody) {
+		// Keep the message on one line, the joined error has a line per error.
+		message = strings.ReplaceAll(message, "\n", ": ")
+	}
+	writeJSON(w, status, Error{Error: ErrorDetails{Code: code, Message: message}})
+}
+
+// writeJSON writes the JSON response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
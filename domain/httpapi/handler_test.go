@@ -0,0 +1,379 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/minimax"
+	"tictactoe/domain/computer/strategies/wiki"
+	"tictactoe/domain/record"
+	"tictactoe/domain/rules"
+)
+
+func lookup(name string) (computer.Strategy, error) {
+	switch name {
+	case "minimax":
+		return minimax.NewStrategy(), nil
+	case "wiki":
+		return wiki.NewStrategy(), nil
+	case "unregistered":
+		return wiki.NewStrategy(), nil
+	}
+	return nil, fmt.Errorf("unknown computer strategy %q", name)
+}
+
+// deadlineStrategy plays the first empty cell and remembers the deadline of the search.
+type deadlineStrategy struct {
+	deadline time.Time
+}
+
+func (s *deadlineStrategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return *b.FindFirstEmptyCell()
+}
+
+func (s *deadlineStrategy) FindBestMoveBefore(r rules.Rules, b board.Board, deadline time.Time) rules.Move {
+	s.deadline = deadline
+	return rules.MoveOf(r, b, s.FindBestCellForNextTurn(b))
+}
+
+func (s *deadlineStrategy) String() string {
+	return "Deadline"
+}
+
+// blockingStrategy plays the first empty cell once the search is released.
+type blockingStrategy struct {
+	searching chan struct{}
+	release   chan struct{}
+}
+
+func (s *blockingStrategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	s.searching <- struct{}{}
+	<-s.release
+	return *b.FindFirstEmptyCell()
+}
+
+func (s *blockingStrategy) String() string {
+	return "Blocking"
+}
+
+// init registers the test strategies, so the handler checks the boards they play.
+func init() {
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:  "deadlinetest",
+		New: func(computer.StrategyOptions) computer.Strategy { return &deadlineStrategy{} },
+	})
+	computer.RegisterStrategy(computer.StrategyInfo{
+		ID:  "blockingtest",
+		New: func(computer.StrategyOptions) computer.Strategy { return &blockingStrategy{} },
+	})
+}
+
+// do sends the request and decodes the response body into v.
+func do(t *testing.T, h http.Handler, method, path string, body any, v any) int {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, &buf))
+	if v != nil {
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(v))
+	}
+	return rec.Code
+}
+
+func createHumanGame(t *testing.T, h http.Handler) Game {
+	t.Helper()
+	var g Game
+	status := do(t, h, http.MethodPost, "/games", CreateGameRequest{
+		PlayerX: record.Player{Name: "Alice"},
+		PlayerO: record.Player{Name: "Bob"},
+	}, &g)
+	require.Equal(t, http.StatusCreated, status)
+	return g
+}
+
+func TestHandler_Create(t *testing.T) {
+	h := NewHandler(lookup)
+	t.Run("when players are humans should create the empty game", func(t *testing.T) {
+		g := createHumanGame(t, h)
+		require.NotEmpty(t, g.ID)
+		require.Equal(t, 3, g.BoardSize)
+		require.Equal(t, 3, g.WinLength)
+		require.Equal(t, []string{"---", "---", "---"}, g.Board)
+		require.Equal(t, "X", g.Turn)
+		require.Equal(t, record.ResultUnfinished, g.Result)
+	})
+	t.Run("when computer plays X should play the first move", func(t *testing.T) {
+		var g Game
+		status := do(t, h, http.MethodPost, "/games", CreateGameRequest{
+			BoardSize: 4,
+			PlayerX:   record.Player{Strategy: "minimax"},
+			PlayerO:   record.Player{Name: "Bob"},
+		}, &g)
+		require.Equal(t, http.StatusCreated, status)
+		require.Equal(t, 4, g.BoardSize)
+		require.Equal(t, 4, g.WinLength)
+		require.Len(t, g.Moves, 1)
+		require.Equal(t, "Computer/Minimax", g.PlayerX.Name)
+		require.Equal(t, "Minimax", g.PlayerX.Strategy)
+		require.Equal(t, "O", g.Turn)
+	})
+	tests := []struct {
+		name       string
+		body       any
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "when strategy is unknown should return unprocessable entity",
+			body:       CreateGameRequest{PlayerX: record.Player{Strategy: "random"}, PlayerO: record.Player{Name: "Bob"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "unknown_strategy",
+		},
+		{
+			name:       "when strategy is found but not registered should return unprocessable entity",
+			body:       CreateGameRequest{PlayerX: record.Player{Strategy: "minimax"}, PlayerO: record.Player{Strategy: "unregistered"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "unknown_strategy",
+		},
+		{
+			name:       "when human has no name should return unprocessable entity",
+			body:       CreateGameRequest{PlayerX: record.Player{Name: "Alice"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "invalid_player",
+		},
+		{
+			name:       "when size is invalid should return unprocessable entity",
+			body:       CreateGameRequest{BoardSize: 100, PlayerX: record.Player{Name: "Alice"}, PlayerO: record.Player{Name: "Bob"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "invalid_size",
+		},
+		{
+			name:       "when strategy doesn't play the board size should return bad request",
+			body:       CreateGameRequest{BoardSize: 5, WinLength: 4, PlayerX: record.Player{Strategy: "wiki"}, PlayerO: record.Player{Name: "Bob"}},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "unsupported_board",
+		},
+		{
+			name:       "when board is too big to search should return bad request",
+			body:       CreateGameRequest{BoardSize: 7, PlayerX: record.Player{Name: "Alice"}, PlayerO: record.Player{Strategy: "minimax"}},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "unsupported_board",
+		},
+		{
+			name:       "when body has unknown fields should return bad request",
+			body:       map[string]any{"players": 2},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_body",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e Error
+			require.Equal(t, tt.wantStatus, do(t, h, http.MethodPost, "/games", tt.body, &e))
+			require.Equal(t, tt.wantCode, e.Error.Code)
+			require.NotEmpty(t, e.Error.Message)
+		})
+	}
+}
+
+func TestHandler_Move(t *testing.T) {
+	h := NewHandler(lookup)
+	t.Run("when humans play should return the new state until the win", func(t *testing.T) {
+		id := createHumanGame(t, h).ID
+		var g Game
+		for _, cell := range []string{"a1", "a2", "b1", "b2", "c1"} {
+			g = Game{}
+			require.Equal(t, http.StatusOK, do(t, h, http.MethodPost, "/games/"+id+"/moves", MoveRequest{Cell: cell}, &g))
+		}
+		require.Equal(t, []string{"XXX", "OO-", "---"}, g.Board)
+		require.Equal(t, record.ResultXWins, g.Result)
+		require.Equal(t, "", g.Turn)
+
+		var e Error
+		require.Equal(t, http.StatusConflict, do(t, h, http.MethodPost, "/games/"+id+"/moves", MoveRequest{Cell: "c3"}, &e))
+		require.Equal(t, ErrorDetails{Code: "game_is_over", Message: board.ErrGameIsOver.Error()}, e.Error)
+	})
+	t.Run("when computer plays O should reply to the move", func(t *testing.T) {
+		var g Game
+		do(t, h, http.MethodPost, "/games", CreateGameRequest{
+			PlayerX: record.Player{Name: "Alice"},
+			PlayerO: record.Player{Strategy: "minimax"},
+		}, &g)
+		require.Equal(t, http.StatusOK, do(t, h, http.MethodPost, "/games/"+g.ID+"/moves", MoveRequest{Cell: "b2"}, &g))
+		require.Len(t, g.Moves, 2)
+		require.Equal(t, "X", g.Turn)
+	})
+	t.Run("when computer moves should search by the move timeout", func(t *testing.T) {
+		s := &deadlineStrategy{}
+		h := NewHandler(func(string) (computer.Strategy, error) { return s, nil }, WithMoveTimeout(time.Minute))
+		var g Game
+		require.Equal(t, http.StatusCreated, do(t, h, http.MethodPost, "/games", CreateGameRequest{
+			PlayerX: record.Player{Strategy: "deadlinetest"},
+			PlayerO: record.Player{Name: "Bob"},
+		}, &g))
+		require.Len(t, g.Moves, 1)
+		require.WithinDuration(t, time.Now().Add(time.Minute), s.deadline, time.Second)
+	})
+	t.Run("when computer searches should serve the game meanwhile", func(t *testing.T) {
+		s := &blockingStrategy{searching: make(chan struct{}), release: make(chan struct{})}
+		h := NewHandler(func(string) (computer.Strategy, error) { return s, nil })
+		var g Game
+		require.Equal(t, http.StatusCreated, do(t, h, http.MethodPost, "/games", CreateGameRequest{
+			PlayerX: record.Player{Name: "Alice"},
+			PlayerO: record.Player{Strategy: "blockingtest"},
+		}, &g))
+		done := make(chan int)
+		go func() {
+			done <- do(t, h, http.MethodPost, "/games/"+g.ID+"/moves", MoveRequest{Cell: "b2"}, nil)
+		}()
+		<-s.searching
+		var during Game
+		require.Equal(t, http.StatusOK, do(t, h, http.MethodGet, "/games/"+g.ID, nil, &during))
+		require.Equal(t, []string{"b2"}, during.Moves)
+		require.Equal(t, "O", during.Turn)
+		var e Error
+		require.Equal(t, http.StatusConflict, do(t, h, http.MethodPost, "/games/"+g.ID+"/moves", MoveRequest{Cell: "c3"}, &e))
+		require.Equal(t, "not_human_turn", e.Error.Code)
+		close(s.release)
+		require.Equal(t, http.StatusOK, <-done)
+		require.Equal(t, http.StatusOK, do(t, h, http.MethodGet, "/games/"+g.ID, nil, &g))
+		require.Equal(t, []string{"b2", "a1"}, g.Moves)
+	})
+	id := createHumanGame(t, h).ID
+	require.Equal(t, http.StatusOK, do(t, h, http.MethodPost, "/games/"+id+"/moves", MoveRequest{Cell: "b2"}, nil))
+	tests := []struct {
+		name       string
+		path       string
+		cell       string
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "when cell is not empty should return conflict",
+			path:       "/games/" + id + "/moves",
+			cell:       "b2",
+			wantStatus: http.StatusConflict,
+			wantCode:   "cell_is_not_empty",
+		},
+		{
+			name:       "when cell is outside the board should return unprocessable entity",
+			path:       "/games/" + id + "/moves",
+			cell:       "d4",
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "invalid_cell",
+		},
+		{
+			name:       "when cell notation is invalid should return unprocessable entity",
+			path:       "/games/" + id + "/moves",
+			cell:       "22",
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "invalid_cell",
+		},
+		{
+			name:       "when game doesn't exist should return not found",
+			path:       "/games/999/moves",
+			cell:       "a1",
+			wantStatus: http.StatusNotFound,
+			wantCode:   "game_not_found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e Error
+			require.Equal(t, tt.wantStatus, do(t, h, http.MethodPost, tt.path, MoveRequest{Cell: tt.cell}, &e))
+			require.Equal(t, tt.wantCode, e.Error.Code)
+		})
+	}
+}
+
+func TestHandler_ListGetDelete(t *testing.T) {
+	h := NewHandler(lookup)
+	first := createHumanGame(t, h)
+	second := createHumanGame(t, h)
+
+	var list GameList
+	require.Equal(t, http.StatusOK, do(t, h, http.MethodGet, "/games", nil, &list))
+	require.Len(t, list.Games, 2)
+	require.Equal(t, first.ID, list.Games[0].ID)
+	require.Equal(t, second.ID, list.Games[1].ID)
+
+	var g Game
+	require.Equal(t, http.StatusOK, do(t, h, http.MethodGet, "/games/"+first.ID, nil, &g))
+	require.Equal(t, first, g)
+
+	require.Equal(t, http.StatusNoContent, do(t, h, http.MethodDelete, "/games/"+first.ID, nil, nil))
+	var e Error
+	require.Equal(t, http.StatusNotFound, do(t, h, http.MethodGet, "/games/"+first.ID, nil, &e))
+	require.Equal(t, "game_not_found", e.Error.Code)
+	require.Equal(t, http.StatusNotFound, do(t, h, http.MethodDelete, "/games/"+first.ID, nil, &e))
+	require.Equal(t, http.StatusMethodNotAllowed, do(t, h, http.MethodPut, "/games/"+second.ID, nil, &e))
+	require.Equal(t, "method_not_allowed", e.Error.Code)
+
+	require.Equal(t, http.StatusOK, do(t, h, http.MethodGet, "/games", nil, &list))
+	require.Len(t, list.Games, 1)
+}
+
+func TestHandler_RouteNotFound(t *testing.T) {
+	h := NewHandler(lookup)
+	id := createHumanGame(t, h).ID
+	for _, path := range []string{"/players", "/games/" + id + "/undo", "/games/" + id + "/moves/a1"} {
+		t.Run("when path is "+path+" should return not found", func(t *testing.T) {
+			var e Error
+			require.Equal(t, http.StatusNotFound, do(t, h, http.MethodGet, path, nil, &e))
+			require.Equal(t, ErrorDetails{Code: "not_found", Message: ErrRouteNotFound.Error()}, e.Error)
+		})
+	}
+}
+
+func TestHandler_ConcurrentMoves(t *testing.T) {
+	h := NewHandler(lookup)
+	id := createHumanGame(t, h).ID
+	const requests = 50
+	statuses := make(chan int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses <- do(t, h, http.MethodPost, "/games/"+id+"/moves", MoveRequest{Cell: "b2"}, nil)
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+	counts := map[int]int{}
+	for s := range statuses {
+		counts[s]++
+	}
+	require.Equal(t, map[int]int{http.StatusOK: 1, http.StatusConflict: requests - 1}, counts)
+
+	var g Game
+	do(t, h, http.MethodGet, "/games/"+id, nil, &g)
+	require.Equal(t, []string{"b2"}, g.Moves)
+}
+
+func TestHandler_ListStrategies(t *testing.T) {
+	h := NewHandler(lookup)
+	var list StrategyList
+	require.Equal(t, http.StatusOK, do(t, h, http.MethodGet, "/strategies", nil, &list))
+	require.Contains(t, list.Strategies, Strategy{
+		ID:          "minimax",
+		Name:        "Minimax",
+		Description: "searches the whole game tree with alpha-beta pruning, never loses",
+	})
+	var e Error
+	require.Equal(t, http.StatusMethodNotAllowed, do(t, h, http.MethodPost, "/strategies", nil, &e))
+}
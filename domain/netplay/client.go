@@ -0,0 +1,369 @@
+package netplay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/record"
+)
+
+// ErrNotConnected is returned when a message is sent while the client is disconnected.
+var ErrNotConnected = errors.New("not connected to the server")
+
+// EventKind is the kind of the event received by the client.
+type EventKind int
+
+const (
+	// EventState means the game state has changed.
+	EventState EventKind = iota + 1
+	// EventJoined means a player has joined or reconnected.
+	EventJoined
+	// EventLeft means a player has disconnected.
+	EventLeft
+	// EventResigned means a player has resigned.
+	EventResigned
+	// EventChat means a player has sent a chat message.
+	EventChat
+	// EventError means the server has rejected the last message.
+	EventError
+	// EventDisconnected means the connection is lost and the client is reconnecting.
+	EventDisconnected
+	// EventReconnected means the client has taken its seat back.
+	EventReconnected
+	// EventClosed means the client won't receive events anymore, it is the last event.
+	EventClosed
+)
+
+// Event is an event received by the client.
+type Event struct {
+	Kind EventKind
+	// Mark is the mark of the player the event is about.
+	Mark board.CellValue
+	// Text is the chat message, the player name of EventJoined or the error message.
+	Text string
+	// State is the game state of EventState.
+	State State
+}
+
+// State is the game state.
+type State struct {
+	Record record.Record
+	Board  board.Board
+}
+
+// IsOver returns true if the game has a result.
+func (s State) IsOver() bool {
+	return s.Record.Result != record.ResultUnfinished
+}
+
+// parseState parses the STATE payload and replays the moves on the board.
+func parseState(payload string) (State, error) {
+	r, err := record.Parse(payload)
+	if err != nil {
+		return State{}, err
+	}
+	b, err := board.New(r.BoardSize, r.WinLength)
+	if err != nil {
+		return State{}, err
+	}
+	for _, m := range r.Moves {
+		cell, err := record.ParseCell(m)
+		if err != nil {
+			return State{}, err
+		}
+		if b, err = b.SetCellValue(cell); err != nil {
+			return State{}, err
+		}
+	}
+	return State{Record: r, Board: b}, nil
+}
+
+const (
+	// defaultReconnectAttempts is the count of attempts to reconnect after the connection is lost.
+	defaultReconnectAttempts = 30
+	// defaultReconnectDelay is the delay between reconnection attempts.
+	defaultReconnectDelay = time.Second
+	// dialTimeout limits the time of a single connection attempt.
+	dialTimeout = 5 * time.Second
+)
+
+// Client is a connection of a player to the Server.
+// Client reconnects automatically when the connection is lost.
+type Client struct {
+	addr              string
+	name              string
+	reconnectAttempts int
+	reconnectDelay    time.Duration
+	events            chan Event
+
+	mu     sync.Mutex
+	conn   net.Conn
+	token  string
+	mark   board.CellValue
+	closed bool
+}
+
+// ClientOption configures the Client.
+type ClientOption func(*Client)
+
+// WithReconnect sets the count of reconnection attempts and the delay between them,
+// zero attempts disable reconnection.
+func WithReconnect(attempts int, delay time.Duration) ClientOption {
+	return func(c *Client) {
+		c.reconnectAttempts = attempts
+		c.reconnectDelay = delay
+	}
+}
+
+// Dial connects the player with the name to the server at the address and joins the game.
+func Dial(addr, name string, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		addr:              addr,
+		name:              name,
+		reconnectAttempts: defaultReconnectAttempts,
+		reconnectDelay:    defaultReconnectDelay,
+		events:            make(chan Event, outboxSize),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	conn, scanner, err := c.connect()
+	if err != nil {
+		return nil, err
+	}
+	go c.read(conn, scanner)
+	return c, nil
+}
+
+// Events returns the channel of received events, it is closed after EventClosed.
+func (c *Client) Events() <-chan Event {
+	return c.events
+}
+
+// Mark returns the mark of the player.
+func (c *Client) Mark() board.CellValue {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mark
+}
+
+// Move plays the cell.
+func (c *Client) Move(cell board.Cell) error {
+	return c.send(Message{Command: CommandMove, Payload: record.FormatCell(cell)})
+}
+
+// Resign gives up the game.
+func (c *Client) Resign() error {
+	return c.send(Message{Command: CommandResign})
+}
+
+// Chat sends the text to every player.
+func (c *Client) Chat(text string) error {
+	return c.send(Message{Command: CommandChat, Payload: text})
+}
+
+// Close disconnects from the server, the seat is kept on the server.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	if c.conn != nil {
+		return c.conn.Close()
+	}
+	return nil
+}
+
+// send writes the message to the server.
+func (c *Client) send(m Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return ErrNotConnected
+	}
+	_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := fmt.Fprintln(c.conn, m.String())
+	return err
+}
+
+// connect dials the server and joins the game, the token is sent to take the seat back.
+func (c *Client) connect() (net.Conn, *bufio.Scanner, error) {
+	conn, err := net.DialTimeout("tcp", c.addr, dialTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+	_ = conn.SetDeadline(time.Now().Add(helloTimeout))
+	if _, err := fmt.Fprintln(conn, helloMessage(c.name, token).String()); err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+	scanner := bufio.NewScanner(conn)
+	if !scanner.Scan() {
+		_ = conn.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrNotConnected
+	}
+	_ = conn.SetDeadline(time.Time{})
+	mark, token, err := parseWelcome(scanner.Text())
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		_ = conn.Close()
+		return nil, nil, ErrNotConnected
+	}
+	c.conn, c.mark, c.token = conn, mark, token
+	return conn, scanner, nil
+}
+
+// parseWelcome parses the server reply to HELLO.
+func parseWelcome(line string) (board.CellValue, string, error) {
+	m, err := ParseMessage(line)
+	if err != nil {
+		return board.EmptyValue, "", err
+	}
+	switch m.Command {
+	case CommandWelcome:
+		mark, token, err := splitMark(m.Payload)
+		if err != nil || token == "" {
+			return board.EmptyValue, "", fmt.Errorf("%w: %q", ErrInvalidMessage, line)
+		}
+		return mark, token, nil
+	case CommandError:
+		return board.EmptyValue, "", serverError(m.Payload)
+	}
+	return board.EmptyValue, "", fmt.Errorf("%w: %s expected", ErrInvalidMessage, CommandWelcome)
+}
+
+// serverError converts the ERROR message to the known error if possible.
+func serverError(text string) error {
+	for _, err := range []error{ErrGameIsFull} {
+		if text == err.Error() {
+			return err
+		}
+	}
+	return errors.New(text)
+}
+
+// read receives events until the client is closed or can't reconnect.
+func (c *Client) read(conn net.Conn, scanner *bufio.Scanner) {
+	defer close(c.events)
+	for {
+		for scanner.Scan() {
+			e, err := parseEvent(scanner.Text())
+			if err != nil {
+				e = Event{Kind: EventError, Text: err.Error()}
+			}
+			c.events <- e
+		}
+		_ = conn.Close()
+
+		c.mu.Lock()
+		c.conn = nil
+		closed := c.closed
+		c.mu.Unlock()
+		if closed {
+			c.events <- Event{Kind: EventClosed}
+			return
+		}
+
+		c.events <- Event{Kind: EventDisconnected}
+		var err error
+		conn, scanner, err = c.reconnect()
+		if err != nil {
+			e := Event{Kind: EventClosed, Text: err.Error()}
+			c.mu.Lock()
+			if c.closed {
+				e.Text = ""
+			}
+			c.mu.Unlock()
+			c.events <- e
+			return
+		}
+		c.events <- Event{Kind: EventReconnected}
+	}
+}
+
+// reconnect tries to connect again until the attempts are exhausted or the client is closed.
+func (c *Client) reconnect() (net.Conn, *bufio.Scanner, error) {
+	err := ErrNotConnected
+	for i := 0; i < c.reconnectAttempts; i++ {
+		time.Sleep(c.reconnectDelay)
+		c.mu.Lock()
+		closed := c.closed
+		c.mu.Unlock()
+		if closed {
+			return nil, nil, ErrNotConnected
+		}
+		var conn net.Conn
+		var scanner *bufio.Scanner
+		conn, scanner, err = c.connect()
+		if err == nil {
+			return conn, scanner, nil
+		}
+		if errors.Is(err, ErrGameIsFull) {
+			break
+		}
+	}
+	return nil, nil, err
+}
+
+// parseEvent parses the server message.
+func parseEvent(line string) (Event, error) {
+	m, err := ParseMessage(line)
+	if err != nil {
+		return Event{}, err
+	}
+	switch m.Command {
+	case CommandState:
+		s, err := parseState(m.Payload)
+		if err != nil {
+			return Event{}, err
+		}
+		return Event{Kind: EventState, State: s}, nil
+	case CommandJoined:
+		mark, quoted, err := splitMark(m.Payload)
+		if err != nil {
+			return Event{}, err
+		}
+		name, err := strconv.Unquote(quoted)
+		if err != nil {
+			return Event{}, fmt.Errorf("%w: %q", ErrInvalidMessage, line)
+		}
+		return Event{Kind: EventJoined, Mark: mark, Text: name}, nil
+	case CommandLeft, CommandResigned:
+		mark, err := parseMark(strings.TrimSpace(m.Payload))
+		if err != nil {
+			return Event{}, err
+		}
+		kind := EventLeft
+		if m.Command == CommandResigned {
+			kind = EventResigned
+		}
+		return Event{Kind: kind, Mark: mark}, nil
+	case CommandChat:
+		mark, text, err := splitMark(m.Payload)
+		if err != nil {
+			return Event{}, err
+		}
+		return Event{Kind: EventChat, Mark: mark, Text: text}, nil
+	case CommandError:
+		return Event{Kind: EventError, Text: m.Payload}, nil
+	}
+	return Event{}, fmt.Errorf("%w: unknown command %q", ErrInvalidMessage, m.Command)
+}
@@ -0,0 +1,102 @@
+package netplay
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/record"
+)
+
+// nextEvent returns the next event of the kind, skipping other events.
+func nextEvent(t *testing.T, c *Client, kind EventKind) Event {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case e, ok := <-c.Events():
+			require.True(t, ok, "events channel is closed")
+			if e.Kind == kind {
+				return e
+			}
+		case <-timeout:
+			require.FailNow(t, "event is not received", "kind %d", kind)
+		}
+	}
+}
+
+func TestClient(t *testing.T) {
+	addr := startServer(t, board.Board{})
+	x, err := Dial(addr, "Alice", WithReconnect(50, 10*time.Millisecond))
+	require.NoError(t, err)
+	defer x.Close()
+	o, err := Dial(addr, "Bob")
+	require.NoError(t, err)
+	defer o.Close()
+	require.Equal(t, board.XValue, x.Mark())
+	require.Equal(t, board.OValue, o.Mark())
+
+	_, err = Dial(addr, "Eve")
+	require.ErrorIs(t, err, ErrGameIsFull)
+
+	joined := nextEvent(t, x, EventJoined)
+	require.Equal(t, board.XValue, joined.Mark)
+	joined = nextEvent(t, x, EventJoined)
+	require.Equal(t, Event{Kind: EventJoined, Mark: board.OValue, Text: "Bob"}, joined)
+
+	t.Run("when player moves should receive the state", func(t *testing.T) {
+		require.NoError(t, x.Move(board.MustNewCell(1, 1)))
+		s := nextEvent(t, o, EventState).State
+		for s.Board.FullCellsCount() == 0 {
+			s = nextEvent(t, o, EventState).State
+		}
+		require.Equal(t, board.XValue, s.Board.CellValue(board.MustNewCell(1, 1)))
+		require.False(t, s.IsOver())
+	})
+	t.Run("when move is rejected should receive the error", func(t *testing.T) {
+		require.NoError(t, x.Move(board.MustNewCell(0, 0)))
+		require.Equal(t, ErrNotYourTurn.Error(), nextEvent(t, x, EventError).Text)
+	})
+	t.Run("when player chats should receive the message", func(t *testing.T) {
+		require.NoError(t, o.Chat("nice move"))
+		require.Equal(t, Event{Kind: EventChat, Mark: board.OValue, Text: "nice move"}, nextEvent(t, x, EventChat))
+	})
+	t.Run("when connection is lost should reconnect to the same seat", func(t *testing.T) {
+		x.mu.Lock()
+		_ = x.conn.Close()
+		x.mu.Unlock()
+		nextEvent(t, x, EventDisconnected)
+		require.Equal(t, board.XValue, nextEvent(t, o, EventLeft).Mark)
+		nextEvent(t, x, EventReconnected)
+		require.Equal(t, board.XValue, x.Mark())
+		require.Equal(t, []string{"b2"}, nextEvent(t, x, EventState).State.Record.Moves)
+	})
+	t.Run("when player resigns should finish the game", func(t *testing.T) {
+		require.NoError(t, o.Resign())
+		require.Equal(t, board.OValue, nextEvent(t, x, EventResigned).Mark)
+		s := nextEvent(t, x, EventState).State
+		require.True(t, s.IsOver())
+		require.Equal(t, record.ResultXWins, s.Record.Result)
+	})
+}
+
+func TestClient_ServerIsGone(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := NewServer(board.Board{})
+	go func() {
+		_ = s.Serve(l)
+	}()
+	c, err := Dial(l.Addr().String(), "Alice", WithReconnect(3, 10*time.Millisecond))
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	nextEvent(t, c, EventDisconnected)
+	require.NotEmpty(t, nextEvent(t, c, EventClosed).Text)
+	_, ok := <-c.Events()
+	require.False(t, ok)
+	require.ErrorIs(t, c.Move(board.MustNewCell(0, 0)), ErrNotConnected)
+}
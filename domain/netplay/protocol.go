@@ -0,0 +1,134 @@
+// Package netplay implements human vs human games over TCP.
+//
+// One instance hosts the game with a Server and every player connects to it with a Client,
+// the server validates every move, so clients only display the state they receive.
+//
+// The wire protocol is line-based: every message is a command optionally followed by a space and a payload,
+// and ends with a newline. A client sends:
+//
+//	HELLO "<name>" [token]  joins the game, the token of a previous WELCOME takes the same seat back
+//	MOVE <cell>             plays the cell written in the record cell notation, e.g. b2
+//	RESIGN                  gives up the game
+//	CHAT <text>             sends the text to every player
+//
+// The server sends:
+//
+//	WELCOME <mark> <token>  the client plays the mark, the token is used to reconnect
+//	STATE <record>          the game state in the record text notation
+//	JOINED <mark> "<name>"  the player has joined or reconnected
+//	LEFT <mark>             the player has disconnected, the seat is kept until the player reconnects
+//	RESIGNED <mark>         the player has resigned
+//	CHAT <mark> <text>      the player has sent the text
+//	ERROR <message>         the last client message was rejected
+package netplay
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"tictactoe/domain/board"
+)
+
+var (
+	// ErrInvalidMessage is returned when a message can't be parsed.
+	ErrInvalidMessage = errors.New("invalid message")
+	// ErrInvalidMark is returned when a mark is neither X nor O.
+	ErrInvalidMark = errors.New("invalid mark, mark must be X or O")
+)
+
+// Command is the message command.
+type Command string
+
+const (
+	// CommandHello joins the game.
+	CommandHello Command = "HELLO"
+	// CommandMove plays a cell.
+	CommandMove Command = "MOVE"
+	// CommandResign gives up the game.
+	CommandResign Command = "RESIGN"
+	// CommandChat sends a chat message.
+	CommandChat Command = "CHAT"
+	// CommandWelcome assigns a mark to the joined client.
+	CommandWelcome Command = "WELCOME"
+	// CommandState sends the game state.
+	CommandState Command = "STATE"
+	// CommandJoined notifies that a player has joined.
+	CommandJoined Command = "JOINED"
+	// CommandLeft notifies that a player has disconnected.
+	CommandLeft Command = "LEFT"
+	// CommandResigned notifies that a player has resigned.
+	CommandResigned Command = "RESIGNED"
+	// CommandError rejects the last client message.
+	CommandError Command = "ERROR"
+)
+
+// Message is a single protocol line.
+type Message struct {
+	Command Command
+	Payload string
+}
+
+// ParseMessage parses the protocol line, the trailing newline is optional.
+func ParseMessage(line string) (Message, error) {
+	line = strings.TrimRight(line, "\r\n")
+	command, payload, _ := strings.Cut(line, " ")
+	if command == "" || strings.ToUpper(command) != command {
+		return Message{}, fmt.Errorf("%w: %q", ErrInvalidMessage, line)
+	}
+	return Message{Command: Command(command), Payload: payload}, nil
+}
+
+// String returns the protocol line without the trailing newline.
+// Newlines in the payload are replaced by spaces, so a message is always a single line.
+func (m Message) String() string {
+	if m.Payload == "" {
+		return string(m.Command)
+	}
+	payload := strings.NewReplacer("\r", " ", "\n", " ").Replace(m.Payload)
+	return string(m.Command) + " " + payload
+}
+
+// formatMark returns the mark as it is written in messages.
+func formatMark(v board.CellValue) string {
+	return v.String()
+}
+
+// parseMark parses the mark written in messages.
+func parseMark(s string) (board.CellValue, error) {
+	switch s {
+	case board.XValue.String():
+		return board.XValue, nil
+	case board.OValue.String():
+		return board.OValue, nil
+	}
+	return board.EmptyValue, ErrInvalidMark
+}
+
+// splitMark splits the payload starting with a mark.
+func splitMark(payload string) (board.CellValue, string, error) {
+	mark, rest, _ := strings.Cut(payload, " ")
+	v, err := parseMark(mark)
+	return v, rest, err
+}
+
+// parseHello parses the HELLO payload: the quoted name and the optional token.
+func parseHello(payload string) (name, token string, err error) {
+	quoted, err := strconv.QuotedPrefix(payload)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: name must be quoted", ErrInvalidMessage)
+	}
+	name, _ = strconv.Unquote(quoted)
+	token = strings.TrimSpace(payload[len(quoted):])
+	return name, token, nil
+}
+
+// helloMessage returns the HELLO message.
+func helloMessage(name, token string) Message {
+	payload := strconv.Quote(name)
+	if token != "" {
+		payload += " " + token
+	}
+	return Message{Command: CommandHello, Payload: payload}
+}
@@ -0,0 +1,64 @@
+package netplay
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    Message
+		wantErr error
+	}{
+		{
+			name: "when message has no payload should parse the command",
+			line: "RESIGN\n",
+			want: Message{Command: CommandResign},
+		},
+		{
+			name: "when message has payload should parse the rest of the line",
+			line: "CHAT X good game, well played\r\n",
+			want: Message{Command: CommandChat, Payload: "X good game, well played"},
+		},
+		{
+			name:    "when line is empty should return error",
+			line:    "\n",
+			wantErr: ErrInvalidMessage,
+		},
+		{
+			name:    "when command is not upper case should return error",
+			line:    "move b2",
+			wantErr: ErrInvalidMessage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMessage(tt.line)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMessage_String(t *testing.T) {
+	require.Equal(t, "RESIGN", Message{Command: CommandResign}.String())
+	require.Equal(t, "MOVE b2", Message{Command: CommandMove, Payload: "b2"}.String())
+	require.Equal(t, "CHAT first second", Message{Command: CommandChat, Payload: "first\nsecond"}.String())
+}
+
+func TestParseHello(t *testing.T) {
+	name, token, err := parseHello(helloMessage("John Doe", "").Payload)
+	require.NoError(t, err)
+	require.Equal(t, "John Doe", name)
+	require.Equal(t, "", token)
+
+	name, token, err = parseHello(helloMessage(`Say "hi"`, "abc").Payload)
+	require.NoError(t, err)
+	require.Equal(t, `Say "hi"`, name)
+	require.Equal(t, "abc", token)
+
+	_, _, err = parseHello("John")
+	require.ErrorIs(t, err, ErrInvalidMessage)
+}
@@ -0,0 +1,400 @@
+package netplay
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/game"
+	"tictactoe/domain/player"
+	"tictactoe/domain/record"
+)
+
+var (
+	// ErrGameIsFull is returned when both seats are taken.
+	ErrGameIsFull = errors.New("game is full")
+	// ErrWaitingForOpponent is returned when a move is played before the opponent has joined.
+	ErrWaitingForOpponent = errors.New("waiting for the opponent to join")
+	// ErrNotYourTurn is returned when a player moves out of turn.
+	ErrNotYourTurn = errors.New("it is not your turn")
+	// ErrServerClosed is returned by Serve after Close.
+	ErrServerClosed = errors.New("server is closed")
+)
+
+const (
+	// helloTimeout limits the time a new connection has to join the game.
+	helloTimeout = 10 * time.Second
+	// writeTimeout limits the time of writing a message to a slow client.
+	writeTimeout = 5 * time.Second
+	// outboxSize is the count of messages queued for a client before it is disconnected as too slow.
+	outboxSize = 64
+)
+
+// Server hosts a single game between two remote players.
+// The first joined player plays X, the second one plays O.
+// A disconnected player keeps the seat and takes it back by reconnecting with the token.
+type Server struct {
+	mu       sync.Mutex
+	board    board.Board
+	started  time.Time
+	game     *game.Game
+	seats    [2]seat
+	listener net.Listener
+	conns    map[net.Conn]bool
+	closed   bool
+}
+
+// seat is a player place in the game.
+type seat struct {
+	name  string
+	token string
+	// peer is the connection of the player, it is nil while the player is disconnected.
+	peer *peer
+}
+
+// NewServer creates a new server of the game on the given board.
+func NewServer(b board.Board) *Server {
+	return &Server{
+		board:   b,
+		started: time.Now().UTC().Truncate(time.Second),
+		conns:   map[net.Conn]bool{},
+	}
+}
+
+// Serve accepts connections on the listener until Close is called.
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrServerClosed
+	}
+	s.listener = l
+	s.mu.Unlock()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// Close stops accepting connections and disconnects all players.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	if s.listener != nil {
+		return s.listener.Close()
+	}
+	return nil
+}
+
+// handle serves a single connection.
+func (s *Server) handle(conn net.Conn) {
+	if !s.addConn(conn) {
+		_ = conn.Close()
+		return
+	}
+	defer s.removeConn(conn)
+
+	scanner := bufio.NewScanner(conn)
+	_ = conn.SetReadDeadline(time.Now().Add(helloTimeout))
+	if !scanner.Scan() {
+		return
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+	mark, p, err := s.join(conn, scanner.Text())
+	if err != nil {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		_, _ = fmt.Fprintln(conn, Message{Command: CommandError, Payload: err.Error()}.String())
+		return
+	}
+	defer p.close()
+	defer s.leave(mark, p)
+
+	for scanner.Scan() {
+		m, err := ParseMessage(scanner.Text())
+		if err == nil {
+			err = s.process(mark, m)
+		}
+		if err != nil {
+			p.send(Message{Command: CommandError, Payload: err.Error()})
+		}
+	}
+}
+
+// addConn registers the connection, so it is closed with the server.
+func (s *Server) addConn(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = true
+	return true
+}
+
+// removeConn closes the connection and forgets it.
+func (s *Server) removeConn(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conns, conn)
+	_ = conn.Close()
+}
+
+// join seats the player that has sent the HELLO line and returns the player mark and connection.
+func (s *Server) join(conn net.Conn, line string) (board.CellValue, *peer, error) {
+	m, err := ParseMessage(line)
+	if err != nil {
+		return board.EmptyValue, nil, err
+	}
+	if m.Command != CommandHello {
+		return board.EmptyValue, nil, fmt.Errorf("%w: %s expected", ErrInvalidMessage, CommandHello)
+	}
+	name, token, err := parseHello(m.Payload)
+	if err != nil {
+		return board.EmptyValue, nil, err
+	}
+	gamePlayer, err := player.New(name)
+	if err != nil {
+		return board.EmptyValue, nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, err := s.findSeat(token)
+	if err != nil {
+		return board.EmptyValue, nil, err
+	}
+	st := &s.seats[i]
+	if st.peer != nil {
+		// The player reconnects before the server has noticed the old connection is lost.
+		st.peer.close()
+	}
+	if st.token == "" {
+		st.name = gamePlayer.Name()
+		st.token = newToken()
+	}
+	p := newPeer(conn)
+	st.peer = p
+	if s.game == nil && s.seats[0].token != "" && s.seats[1].token != "" {
+		s.game = game.NewWithBoard(s.board,
+			player.MustNew(s.seats[0].name),
+			player.MustNew(s.seats[1].name),
+		)
+	}
+
+	mark := seatMark(i)
+	p.send(Message{Command: CommandWelcome, Payload: formatMark(mark) + " " + st.token})
+	s.broadcast(Message{Command: CommandJoined, Payload: formatMark(mark) + " " + strconv.Quote(st.name)})
+	s.broadcast(s.stateMessage())
+	return mark, p, nil
+}
+
+// findSeat returns the index of the seat taken by the token or of the first free seat.
+func (s *Server) findSeat(token string) (int, error) {
+	if token != "" {
+		for i, st := range s.seats {
+			if st.token == token {
+				return i, nil
+			}
+		}
+	}
+	for i, st := range s.seats {
+		if st.token == "" {
+			return i, nil
+		}
+	}
+	return 0, ErrGameIsFull
+}
+
+// leave frees the connection of the seat, the seat is kept for the reconnection.
+func (s *Server) leave(mark board.CellValue, p *peer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := &s.seats[seatIndex(mark)]
+	if st.peer != p {
+		// The player has already reconnected.
+		return
+	}
+	st.peer = nil
+	s.broadcast(Message{Command: CommandLeft, Payload: formatMark(mark)})
+}
+
+// process handles the message sent by the player with the mark.
+func (s *Server) process(mark board.CellValue, m Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch m.Command {
+	case CommandMove:
+		cell, err := record.ParseCell(m.Payload)
+		if err != nil {
+			return err
+		}
+		if err := s.checkCanPlay(mark); err != nil {
+			return err
+		}
+		if err := s.game.Play(cell); err != nil {
+			return err
+		}
+		s.broadcast(s.stateMessage())
+	case CommandResign:
+		if err := s.checkIsNotOver(); err != nil {
+			return err
+		}
+		if err := s.game.Resign(mark); err != nil {
+			return err
+		}
+		s.broadcast(Message{Command: CommandResigned, Payload: formatMark(mark)})
+		s.broadcast(s.stateMessage())
+	case CommandChat:
+		s.broadcast(Message{Command: CommandChat, Payload: formatMark(mark) + " " + m.Payload})
+	default:
+		return fmt.Errorf("%w: unknown command %q", ErrInvalidMessage, m.Command)
+	}
+	return nil
+}
+
+// checkCanPlay returns an error if the player with the mark can't play now.
+func (s *Server) checkCanPlay(mark board.CellValue) error {
+	if err := s.checkIsNotOver(); err != nil {
+		return err
+	}
+	if s.game.GetBoard().CurrentTurnCellValue() != mark {
+		return ErrNotYourTurn
+	}
+	return nil
+}
+
+// checkIsNotOver returns an error if the game is not started or is over.
+func (s *Server) checkIsNotOver() error {
+	if s.game == nil {
+		return ErrWaitingForOpponent
+	}
+	if s.game.IsOver() {
+		return board.ErrGameIsOver
+	}
+	return nil
+}
+
+// stateMessage returns the STATE message of the current game.
+func (s *Server) stateMessage() Message {
+	r := record.Record{
+		Date:      s.started,
+		BoardSize: s.board.Size(),
+		WinLength: s.board.WinLength(),
+		PlayerX:   record.Player{Name: s.seats[0].name},
+		PlayerO:   record.Player{Name: s.seats[1].name},
+		Moves:     []string{},
+		Result:    record.ResultUnfinished,
+	}
+	if s.game != nil {
+		r = record.FromGame(s.game, s.started)
+	}
+	return Message{Command: CommandState, Payload: r.String()}
+}
+
+// broadcast sends the message to all connected players.
+func (s *Server) broadcast(m Message) {
+	for _, st := range s.seats {
+		if st.peer != nil {
+			st.peer.send(m)
+		}
+	}
+}
+
+// seatMark returns the mark of the seat with the index.
+func seatMark(i int) board.CellValue {
+	if i == 0 {
+		return board.XValue
+	}
+	return board.OValue
+}
+
+// seatIndex returns the index of the seat of the mark.
+func seatIndex(mark board.CellValue) int {
+	if mark == board.XValue {
+		return 0
+	}
+	return 1
+}
+
+// newToken returns a random reconnection token.
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// peer is a connection with the queue of outgoing messages.
+// Messages are written by a separate goroutine, so a slow client doesn't block the server.
+type peer struct {
+	conn      net.Conn
+	outbox    chan Message
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// newPeer creates a peer and starts writing its messages.
+func newPeer(conn net.Conn) *peer {
+	p := &peer{
+		conn:   conn,
+		outbox: make(chan Message, outboxSize),
+		done:   make(chan struct{}),
+	}
+	go p.write()
+	return p
+}
+
+// send queues the message, the peer is closed if the queue is full.
+func (p *peer) send(m Message) {
+	select {
+	case <-p.done:
+	case p.outbox <- m:
+	default:
+		p.close()
+	}
+}
+
+// write writes the queued messages until the peer is closed.
+func (p *peer) write() {
+	for {
+		select {
+		case <-p.done:
+			return
+		case m := <-p.outbox:
+			_ = p.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if _, err := fmt.Fprintln(p.conn, m.String()); err != nil {
+				p.close()
+				return
+			}
+		}
+	}
+}
+
+// close stops writing messages and closes the connection.
+func (p *peer) close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+		_ = p.conn.Close()
+	})
+}
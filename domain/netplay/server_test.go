@@ -0,0 +1,193 @@
+package netplay
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/record"
+)
+
+// startServer starts a server on a random local port and returns its address.
+func startServer(t *testing.T, b board.Board) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := NewServer(b)
+	go func() {
+		_ = s.Serve(l)
+	}()
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	return l.Addr().String()
+}
+
+// rawClient speaks the protocol directly.
+type rawClient struct {
+	t       *testing.T
+	conn    net.Conn
+	scanner *bufio.Scanner
+}
+
+func dialRaw(t *testing.T, addr string) *rawClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return &rawClient{t: t, conn: conn, scanner: bufio.NewScanner(conn)}
+}
+
+func (c *rawClient) send(line string) {
+	c.t.Helper()
+	_, err := fmt.Fprintln(c.conn, line)
+	require.NoError(c.t, err)
+}
+
+// expect reads lines until the line with the prefix and returns it.
+func (c *rawClient) expect(prefix string) string {
+	c.t.Helper()
+	_ = c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for c.scanner.Scan() {
+		if strings.HasPrefix(c.scanner.Text(), prefix) {
+			return c.scanner.Text()
+		}
+	}
+	require.FailNow(c.t, "line is not received", "prefix %q, error %v", prefix, c.scanner.Err())
+	return ""
+}
+
+// expectState reads lines until the STATE line and returns its record.
+func (c *rawClient) expectState() record.Record {
+	c.t.Helper()
+	s, err := parseState(strings.TrimPrefix(c.expect("STATE "), "STATE "))
+	require.NoError(c.t, err)
+	return s.Record
+}
+
+// joinBoth joins two players and returns them after the game has started.
+func joinBoth(t *testing.T, addr string) (x, o *rawClient) {
+	x = dialRaw(t, addr)
+	x.send(`HELLO "Alice"`)
+	require.True(t, strings.HasPrefix(x.expect("WELCOME "), "WELCOME X "))
+	o = dialRaw(t, addr)
+	o.send(`HELLO "Bob"`)
+	require.True(t, strings.HasPrefix(o.expect("WELCOME "), "WELCOME O "))
+	require.Equal(t, `JOINED O "Bob"`, x.expect("JOINED O"))
+	r := x.expectState()
+	require.Equal(t, "Alice", r.PlayerX.Name)
+	require.Equal(t, "Bob", r.PlayerO.Name)
+	o.expectState()
+	return x, o
+}
+
+func TestServer_Play(t *testing.T) {
+	t.Run("when players play in turns should broadcast the state until the win", func(t *testing.T) {
+		x, o := joinBoth(t, startServer(t, board.Board{}))
+		moves := []*rawClient{x, o, x, o, x}
+		cells := []string{"a1", "a2", "b1", "b2", "c1"}
+		var r record.Record
+		for i, c := range moves {
+			c.send("MOVE " + cells[i])
+			r = x.expectState()
+			require.Equal(t, r, o.expectState())
+		}
+		require.Equal(t, cells, r.Moves)
+		require.Equal(t, record.ResultXWins, r.Result)
+
+		o.send("MOVE c3")
+		require.Equal(t, "ERROR "+board.ErrGameIsOver.Error(), o.expect("ERROR"))
+	})
+	t.Run("when move is invalid should return error to the sender", func(t *testing.T) {
+		x, o := joinBoth(t, startServer(t, board.Board{}))
+		o.send("MOVE b2")
+		require.Equal(t, "ERROR "+ErrNotYourTurn.Error(), o.expect("ERROR"))
+		x.send("MOVE b2")
+		x.expectState()
+		o.send("MOVE b2")
+		require.Equal(t, "ERROR "+board.ErrCellIsNotEmpty.Error(), o.expect("ERROR"))
+		o.send("MOVE z9")
+		require.Equal(t, "ERROR "+board.ErrInvalidCell.Error(), o.expect("ERROR"))
+		o.send("JUMP")
+		require.True(t, strings.HasPrefix(o.expect("ERROR"), "ERROR "+ErrInvalidMessage.Error()))
+	})
+	t.Run("when opponent has not joined should not allow moves", func(t *testing.T) {
+		x := dialRaw(t, startServer(t, board.Board{}))
+		x.send(`HELLO "Alice"`)
+		x.expect("WELCOME X")
+		x.send("MOVE b2")
+		require.Equal(t, "ERROR "+ErrWaitingForOpponent.Error(), x.expect("ERROR"))
+	})
+	t.Run("when board is bigger should play on it", func(t *testing.T) {
+		x, _ := joinBoth(t, startServer(t, board.MustNew(5, 4)))
+		x.send("MOVE e5")
+		r := x.expectState()
+		require.Equal(t, 5, r.BoardSize)
+		require.Equal(t, 4, r.WinLength)
+		require.Equal(t, []string{"e5"}, r.Moves)
+	})
+}
+
+func TestServer_ResignAndChat(t *testing.T) {
+	x, o := joinBoth(t, startServer(t, board.Board{}))
+	o.send("CHAT good luck")
+	require.Equal(t, "CHAT O good luck", x.expect("CHAT"))
+	require.Equal(t, "CHAT O good luck", o.expect("CHAT"))
+
+	x.send("RESIGN")
+	require.Equal(t, "RESIGNED X", o.expect("RESIGNED"))
+	state := o.expectState()
+	require.Equal(t, record.ResultOWins, state.Result)
+	require.Equal(t, record.TerminationResignation, state.Termination)
+	o.send("RESIGN")
+	require.Equal(t, "ERROR "+board.ErrGameIsOver.Error(), o.expect("ERROR"))
+}
+
+func TestServer_Join(t *testing.T) {
+	t.Run("when both seats are taken should reject the player", func(t *testing.T) {
+		addr := startServer(t, board.Board{})
+		joinBoth(t, addr)
+		c := dialRaw(t, addr)
+		c.send(`HELLO "Eve"`)
+		require.Equal(t, "ERROR "+ErrGameIsFull.Error(), c.expect("ERROR"))
+	})
+	t.Run("when first message is not hello should reject the connection", func(t *testing.T) {
+		c := dialRaw(t, startServer(t, board.Board{}))
+		c.send("MOVE b2")
+		require.True(t, strings.HasPrefix(c.expect("ERROR"), "ERROR "+ErrInvalidMessage.Error()))
+	})
+	t.Run("when player reconnects with the token should take the seat back", func(t *testing.T) {
+		addr := startServer(t, board.Board{})
+		x := dialRaw(t, addr)
+		x.send(`HELLO "Alice"`)
+		token := strings.TrimPrefix(x.expect("WELCOME"), "WELCOME X ")
+		o := dialRaw(t, addr)
+		o.send(`HELLO "Bob"`)
+		o.expect("WELCOME O")
+		x.send("MOVE b2")
+		o.expectState()
+		o.expectState()
+
+		_ = x.conn.Close()
+		require.Equal(t, "LEFT X", o.expect("LEFT"))
+		eve := dialRaw(t, addr)
+		eve.send(`HELLO "Eve"`)
+		require.Equal(t, "ERROR "+ErrGameIsFull.Error(), eve.expect("ERROR"))
+
+		x = dialRaw(t, addr)
+		x.send(`HELLO "Alice" ` + token)
+		require.Equal(t, "WELCOME X "+token, x.expect("WELCOME"))
+		require.Equal(t, `JOINED X "Alice"`, o.expect("JOINED"))
+		require.Equal(t, []string{"b2"}, x.expectState().Moves)
+		o.send("MOVE a1")
+		require.Equal(t, []string{"b2", "a1"}, x.expectState().Moves)
+	})
+}
@@ -0,0 +1,183 @@
+package record
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/rules"
+)
+
+var (
+	// ErrInvalidCellNotation is returned when a cell notation can't be parsed.
+	ErrInvalidCellNotation = errors.New("invalid cell notation, it must be a column letter followed by a row number, e.g. b2")
+	// ErrInvalidMarkedCellNotation is returned when a move of the wild variant can't be parsed.
+	ErrInvalidMarkedCellNotation = errors.New("invalid marked cell notation, it must be X or O followed by the cell notation, e.g. Ob2")
+	// ErrInvalidNotation is returned when a record notation can't be parsed.
+	ErrInvalidNotation = errors.New("invalid record notation")
+)
+
+// FormatCell returns the cell notation: the column letter followed by the row number, e.g. "b2".
+func FormatCell(c board.Cell) string {
+	return fmt.Sprintf("%c%d", 'a'+c.ColumnNumber, c.RowNumber+1)
+}
+
+// FormatMarkedCell returns the marked cell notation of the wild variant moves: the mark followed by the cell notation, e.g. "Ob2".
+func FormatMarkedCell(m rules.Move) string {
+	return m.Mark.String() + FormatCell(m.Cell)
+}
+
+// ParseMarkedCell parses the marked cell notation.
+func ParseMarkedCell(s string) (rules.Move, error) {
+	if s == "" {
+		return rules.Move{}, ErrInvalidMarkedCellNotation
+	}
+	var mark board.CellValue
+	switch s[0] {
+	case 'X':
+		mark = board.XValue
+	case 'O':
+		mark = board.OValue
+	default:
+		return rules.Move{}, ErrInvalidMarkedCellNotation
+	}
+	cell, err := ParseCell(s[1:])
+	if err != nil {
+		return rules.Move{}, err
+	}
+	return rules.Move{Cell: cell, Mark: mark}, nil
+}
+
+// ParseCell parses the cell notation.
+func ParseCell(s string) (board.Cell, error) {
+	if len(s) < 2 || s[0] < 'a' || s[0] > 'z' {
+		return board.Cell{}, ErrInvalidCellNotation
+	}
+	row, err := strconv.Atoi(s[1:])
+	if err != nil {
+		return board.Cell{}, ErrInvalidCellNotation
+	}
+	return board.NewCell(row-1, int(s[0]-'a'))
+}
+
+// String returns the record in the compact single-line text notation, e.g.:
+//
+//	date=2023-10-01T12:00:00Z size=3 win=3 x="Player" o="Computer/Minimax" o.strategy="Minimax" moves=b2,a1,c3 result=*
+//
+// Strategies are written only for computer players, the variant is written only if it is not classic,
+// the rows are written only if the board is not square, the termination is written only if it is not empty.
+func (r Record) String() string {
+	fields := []string{
+		"date=" + r.Date.Format(time.RFC3339),
+		"size=" + strconv.Itoa(r.BoardSize),
+	}
+	if r.BoardRows != 0 {
+		fields = append(fields, "rows="+strconv.Itoa(r.BoardRows))
+	}
+	fields = append(fields, "win="+strconv.Itoa(r.WinLength))
+	if r.Variant != "" {
+		fields = append(fields, "variant="+r.Variant)
+	}
+	fields = append(fields, "x="+strconv.Quote(r.PlayerX.Name))
+	if r.PlayerX.Strategy != "" {
+		fields = append(fields, "x.strategy="+strconv.Quote(r.PlayerX.Strategy))
+	}
+	fields = append(fields, "o="+strconv.Quote(r.PlayerO.Name))
+	if r.PlayerO.Strategy != "" {
+		fields = append(fields, "o.strategy="+strconv.Quote(r.PlayerO.Strategy))
+	}
+	fields = append(fields,
+		"moves="+strings.Join(r.Moves, ","),
+		"result="+string(r.Result),
+	)
+	if r.Termination != "" {
+		fields = append(fields, "termination="+string(r.Termination))
+	}
+	return strings.Join(fields, " ")
+}
+
+// Parse parses the record in the text notation, see Record.String.
+func Parse(s string) (Record, error) {
+	var r Record
+	seen := map[string]bool{}
+	s = strings.TrimSpace(s)
+	for s != "" {
+		key, value, rest, err := nextField(s)
+		if err != nil {
+			return Record{}, err
+		}
+		s = strings.TrimLeft(rest, " ")
+		if seen[key] {
+			return Record{}, fmt.Errorf("%w: duplicated field %q", ErrInvalidNotation, key)
+		}
+		seen[key] = true
+		switch key {
+		case "date":
+			r.Date, err = time.Parse(time.RFC3339, value)
+		case "size":
+			r.BoardSize, err = strconv.Atoi(value)
+		case "rows":
+			r.BoardRows, err = strconv.Atoi(value)
+		case "win":
+			r.WinLength, err = strconv.Atoi(value)
+		case "variant":
+			r.Variant = value
+		case "x":
+			r.PlayerX.Name = value
+		case "x.strategy":
+			r.PlayerX.Strategy = value
+		case "o":
+			r.PlayerO.Name = value
+		case "o.strategy":
+			r.PlayerO.Strategy = value
+		case "moves":
+			r.Moves = []string{}
+			if value != "" {
+				r.Moves = strings.Split(value, ",")
+			}
+		case "result":
+			r.Result = Result(value)
+			if !r.Result.IsValid() {
+				err = ErrInvalidResult
+			}
+		case "termination":
+			r.Termination = Termination(value)
+			if !r.Termination.IsValid() {
+				err = ErrInvalidTermination
+			}
+		default:
+			return Record{}, fmt.Errorf("%w: unknown field %q", ErrInvalidNotation, key)
+		}
+		if err != nil {
+			return Record{}, fmt.Errorf("%w: field %q: %w", ErrInvalidNotation, key, err)
+		}
+	}
+	for _, key := range []string{"date", "size", "win", "x", "o", "moves", "result"} {
+		if !seen[key] {
+			return Record{}, fmt.Errorf("%w: missing field %q", ErrInvalidNotation, key)
+		}
+	}
+	return r, nil
+}
+
+// nextField reads the key=value field from the beginning of s.
+// The value is either quoted or lasts until the next space.
+func nextField(s string) (key, value, rest string, err error) {
+	key, rest, ok := strings.Cut(s, "=")
+	if !ok || key == "" || strings.Contains(key, " ") {
+		return "", "", "", fmt.Errorf("%w: field must be key=value", ErrInvalidNotation)
+	}
+	if strings.HasPrefix(rest, `"`) {
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return "", "", "", fmt.Errorf("%w: field %q: %w", ErrInvalidNotation, key, err)
+		}
+		value, _ = strconv.Unquote(quoted)
+		return key, value, rest[len(quoted):], nil
+	}
+	value, rest, _ = strings.Cut(rest, " ")
+	return key, value, rest, nil
+}
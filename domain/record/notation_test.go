@@ -0,0 +1,120 @@
+package record
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"tictactoe/domain/board"
+	"tictactoe/domain/rules"
+	"time"
+)
+
+func TestFormatCell(t *testing.T) {
+	require.Equal(t, "a1", FormatCell(board.MustNewCell(0, 0)))
+	require.Equal(t, "c2", FormatCell(board.MustNewCell(1, 2)))
+	require.Equal(t, "o15", FormatCell(board.MustNewCell(14, 14)))
+}
+
+func TestParseCell(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    board.Cell
+		wantErr error
+	}{
+		{name: "valid", s: "b3", want: board.MustNewCell(2, 1)},
+		{name: "valid two digits row", s: "a12", want: board.MustNewCell(11, 0)},
+		{name: "empty", s: "", wantErr: ErrInvalidCellNotation},
+		{name: "no row", s: "a", wantErr: ErrInvalidCellNotation},
+		{name: "upper case column", s: "A1", wantErr: ErrInvalidCellNotation},
+		{name: "invalid row", s: "ax", wantErr: ErrInvalidCellNotation},
+		{name: "zero row", s: "a0", wantErr: board.ErrInvalidCell},
+		{name: "too big column", s: "z1", wantErr: board.ErrInvalidCell},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCell(tt.s)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseMarkedCell(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    rules.Move
+		wantErr error
+	}{
+		{name: "valid", s: "Ob3", want: rules.Move{Cell: board.MustNewCell(2, 1), Mark: board.OValue}},
+		{name: "empty", s: "", wantErr: ErrInvalidMarkedCellNotation},
+		{name: "no mark", s: "b3", wantErr: ErrInvalidMarkedCellNotation},
+		{name: "no cell", s: "X", wantErr: ErrInvalidCellNotation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMarkedCell(tt.s)
+			require.Equal(t, tt.wantErr, err)
+			require.Equal(t, tt.want, got)
+			if err == nil {
+				require.Equal(t, tt.s, FormatMarkedCell(got))
+			}
+		})
+	}
+}
+
+func TestRecord_String(t *testing.T) {
+	r := Record{
+		Date:      time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC),
+		BoardSize: 3,
+		WinLength: 3,
+		PlayerX:   Player{Name: "Player 1"},
+		PlayerO:   Player{Name: "Computer/Minimax", Strategy: "Minimax"},
+		Moves:     []string{"b2", "a1", "c3"},
+		Result:    ResultUnfinished,
+	}
+	want := `date=2023-10-01T12:00:00Z size=3 win=3 x="Player 1" o="Computer/Minimax" o.strategy="Minimax" moves=b2,a1,c3 result=*`
+	require.Equal(t, want, r.String())
+	t.Run("when game is won by resignation should write the termination", func(t *testing.T) {
+		r := r
+		r.Result, r.Termination = ResultOWins, TerminationResignation
+		require.True(t, strings.HasSuffix(r.String(), " result=0-1 termination=resignation"))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("should parse fields in any order", func(t *testing.T) {
+		got, err := Parse(`result=1/2-1/2 moves= x.strategy="Wiki" x="Computer \"Wiki\"" o="Jane" win=4 size=5 date=2023-10-01T12:00:00Z`)
+		require.NoError(t, err)
+		require.Equal(t, Record{
+			Date:      time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC),
+			BoardSize: 5,
+			WinLength: 4,
+			PlayerX:   Player{Name: `Computer "Wiki"`, Strategy: "Wiki"},
+			PlayerO:   Player{Name: "Jane"},
+			Moves:     []string{},
+			Result:    ResultDraw,
+		}, got)
+	})
+	tests := []struct {
+		name string
+		s    string
+	}{
+		{name: "missing field", s: `date=2023-10-01T12:00:00Z size=3 win=3 x="John" moves= result=*`},
+		{name: "unknown field", s: `date=2023-10-01T12:00:00Z size=3 win=3 x="John" o="Jane" moves= result=* color=red`},
+		{name: "duplicated field", s: `date=2023-10-01T12:00:00Z size=3 size=3 win=3 x="John" o="Jane" moves= result=*`},
+		{name: "invalid size", s: `date=2023-10-01T12:00:00Z size=three win=3 x="John" o="Jane" moves= result=*`},
+		{name: "invalid date", s: `date=yesterday size=3 win=3 x="John" o="Jane" moves= result=*`},
+		{name: "invalid result", s: `date=2023-10-01T12:00:00Z size=3 win=3 x="John" o="Jane" moves= result=2-0`},
+		{name: "invalid termination", s: `date=2023-10-01T12:00:00Z size=3 win=3 x="John" o="Jane" moves= result=1-0 termination=abandoned`},
+		{name: "unterminated quote", s: `date=2023-10-01T12:00:00Z size=3 win=3 x="John o="Jane" moves= result=*`},
+		{name: "not key value", s: `date=2023-10-01T12:00:00Z size=3 win=3 x="John" o="Jane" moves= result=* oops`},
+	}
+	for _, tt := range tests {
+		t.Run("when "+tt.name+" should return error", func(t *testing.T) {
+			_, err := Parse(tt.s)
+			require.ErrorIs(t, err, ErrInvalidNotation)
+		})
+	}
+}
@@ -0,0 +1,320 @@
+// Package record implements a portable game record format.
+//
+// A record contains the date, the board dimensions, the players with their strategies,
+// the list of moves and the result of a game. A record can be encoded as JSON:
+//
+//	{
+//	  "date": "2023-10-01T12:00:00Z",
+//	  "board_size": 3,
+//	  "win_length": 3,
+//	  "player_x": {"name": "Player"},
+//	  "player_o": {"name": "Computer/Minimax", "strategy": "Minimax"},
+//	  "moves": ["b2", "a1", "c3"],
+//	  "result": "*"
+//	}
+//
+// or as the compact single-line text notation, see Record.String.
+// Games of the misere variant also have "variant": "misere", the field is omitted for the classic variant.
+// Moves are written in the cell notation: the column letter followed by the row number,
+// so "a1" is the top left cell and "c3" is the bottom right cell of the classic board.
+// Moves of the wild variant are prefixed by the placed mark, e.g. "Ob2".
+// Result is "1-0" when X wins, "0-1" when O wins, "1/2-1/2" in a draw and "*" when the game is not finished.
+// A game won before the end on the board also has "termination": "time" when the flag of the loser has fallen
+// or "termination": "resignation" when the loser has resigned.
+package record
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+	"unicode"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/game"
+	"tictactoe/domain/player"
+	"tictactoe/domain/rules"
+)
+
+var (
+	// ErrResultMismatch is returned when the recorded result doesn't match the result of the replayed moves.
+	ErrResultMismatch = errors.New("recorded result doesn't match the moves")
+	// ErrInvalidResult is returned when a result is unknown.
+	ErrInvalidResult = errors.New("invalid result")
+	// ErrInvalidTermination is returned when a termination is unknown.
+	ErrInvalidTermination = errors.New("invalid termination")
+)
+
+// Result is the result of a recorded game.
+type Result string
+
+const (
+	// ResultXWins means X has won.
+	ResultXWins Result = "1-0"
+	// ResultOWins means O has won.
+	ResultOWins Result = "0-1"
+	// ResultDraw means the game ended in a draw.
+	ResultDraw Result = "1/2-1/2"
+	// ResultUnfinished means the game is not finished.
+	ResultUnfinished Result = "*"
+)
+
+// IsValid returns true if the result is known.
+func (r Result) IsValid() bool {
+	switch r {
+	case ResultXWins, ResultOWins, ResultDraw, ResultUnfinished:
+		return true
+	}
+	return false
+}
+
+// Termination is the way a recorded game has been won before the end on the board.
+type Termination string
+
+const (
+	// TerminationTime means the flag of the loser has fallen.
+	TerminationTime Termination = "time"
+	// TerminationResignation means the loser has resigned.
+	TerminationResignation Termination = "resignation"
+)
+
+// IsValid returns true if the termination is known or empty.
+func (t Termination) IsValid() bool {
+	switch t {
+	case "", TerminationTime, TerminationResignation:
+		return true
+	}
+	return false
+}
+
+// Player is a recorded player.
+// Strategy is the name of the computer player strategy, it is empty for a human player.
+type Player struct {
+	Name     string `json:"name"`
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// Record is a game record.
+type Record struct {
+	Date      time.Time `json:"date"`
+	BoardSize int       `json:"board_size"`
+	// BoardRows is the count of rows of a rectangular board, zero means the board is square.
+	BoardRows int      `json:"board_rows,omitempty"`
+	WinLength int      `json:"win_length"`
+	Variant   string   `json:"variant,omitempty"`
+	PlayerX   Player   `json:"player_x"`
+	PlayerO   Player   `json:"player_o"`
+	Moves     []string `json:"moves"`
+	Result    Result   `json:"result"`
+	// Termination is empty unless the game has been won on time or by resignation.
+	Termination Termination `json:"termination,omitempty"`
+}
+
+// StrategyLookup returns the computer strategy by its name.
+type StrategyLookup func(name string) (computer.Strategy, error)
+
+// FromGame creates a record of the game played on the given date.
+// The game must be started from the empty board.
+func FromGame(g *game.Game, date time.Time) Record {
+	player1, player2 := g.Players()
+	b := g.GetBoard()
+	r := Record{
+		Date:      date,
+		BoardSize: b.Size(),
+		WinLength: b.WinLength(),
+		PlayerX:   playerOf(player1),
+		PlayerO:   playerOf(player2),
+		Moves:     make([]string, 0, len(g.Moves())),
+		Result:    resultOf(b),
+	}
+	if side, ok := g.Flagged(); ok {
+		r.Result, r.Termination = lossOf(side), TerminationTime
+	}
+	if side, ok := g.Resigned(); ok {
+		r.Result, r.Termination = lossOf(side), TerminationResignation
+	}
+	if b.Rows() != b.Columns() {
+		r.BoardRows = b.Rows()
+	}
+	if b.Variant() != board.Classic {
+		r.Variant = b.Variant().String()
+	}
+	for _, m := range g.Moves() {
+		if b.Variant() == board.Wild {
+			r.Moves = append(r.Moves, FormatMarkedCell(rules.Move{Cell: m.Cell, Mark: m.CellValue}))
+			continue
+		}
+		r.Moves = append(r.Moves, FormatCell(m.Cell))
+	}
+	return r
+}
+
+// playerOf returns the record of the game player.
+func playerOf(p game.Player) Player {
+	r := Player{Name: p.Name()}
+	if c, ok := p.(computer.Player); ok {
+		r.Strategy = c.Strategy().String()
+	}
+	return r
+}
+
+// resultOf returns the result of the game on the board.
+func resultOf(b board.Board) Result {
+	if w, ok := b.Winner(); ok {
+		if w == board.XValue {
+			return ResultXWins
+		}
+		return ResultOWins
+	}
+	if b.IsFull() {
+		return ResultDraw
+	}
+	return ResultUnfinished
+}
+
+// lossOf returns the result of the game lost by the side before the end on the board.
+func lossOf(side board.CellValue) Result {
+	if side == board.XValue {
+		return ResultOWins
+	}
+	return ResultXWins
+}
+
+// Game replays the recorded moves and returns the game, so an unfinished game can be resumed.
+// Computer players strategies are found by the lookup.
+func (r Record) Game(lookup StrategyLookup) (*game.Game, error) {
+	if !r.Result.IsValid() {
+		return nil, ErrInvalidResult
+	}
+	if !r.Termination.IsValid() {
+		return nil, ErrInvalidTermination
+	}
+	rows := r.BoardRows
+	if rows == 0 {
+		rows = r.BoardSize
+	}
+	b, err := board.NewRectangular(rows, r.BoardSize, r.WinLength)
+	if err != nil {
+		return nil, err
+	}
+	if r.Variant != "" {
+		v, err := board.ParseVariant(r.Variant)
+		if err != nil {
+			return nil, err
+		}
+		b = b.WithVariant(v)
+	}
+	player1, err := r.PlayerX.gamePlayer(lookup)
+	if err != nil {
+		return nil, err
+	}
+	player2, err := r.PlayerO.gamePlayer(lookup)
+	if err != nil {
+		return nil, err
+	}
+	g := game.NewWithBoard(b, player1, player2)
+	for _, s := range r.Moves {
+		m, err := parseMove(s, g)
+		if err != nil {
+			return nil, err
+		}
+		if err := g.PlayMove(m); err != nil {
+			return nil, err
+		}
+	}
+	if r.Termination != "" {
+		if err := r.terminate(g); err != nil {
+			return nil, err
+		}
+		return g, nil
+	}
+	if resultOf(g.GetBoard()) != r.Result {
+		return nil, ErrResultMismatch
+	}
+	return g, nil
+}
+
+// terminate ends the replayed game on time or by resignation of the loser.
+// The recorded result must be a win, and the game must not be over on the board.
+func (r Record) terminate(g *game.Game) error {
+	var loser board.CellValue
+	switch r.Result {
+	case ResultXWins:
+		loser = board.OValue
+	case ResultOWins:
+		loser = board.XValue
+	default:
+		return ErrResultMismatch
+	}
+	end := g.Resign
+	if r.Termination == TerminationTime {
+		end = g.Flag
+	}
+	if err := end(loser); err != nil {
+		return ErrResultMismatch
+	}
+	return nil
+}
+
+// parseMove parses the recorded move of the game, the mark is written only in the wild variant.
+func parseMove(s string, g *game.Game) (rules.Move, error) {
+	if g.GetBoard().Variant() == board.Wild {
+		return ParseMarkedCell(s)
+	}
+	cell, err := ParseCell(s)
+	if err != nil {
+		return rules.Move{}, err
+	}
+	return rules.MoveOf(g.Rules(), g.GetBoard(), cell), nil
+}
+
+// gamePlayer returns the game player of the recorded player.
+func (p Player) gamePlayer(lookup StrategyLookup) (game.Player, error) {
+	if p.Strategy == "" {
+		return player.New(p.Name)
+	}
+	s, err := lookup(p.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	return computer.New(s), nil
+}
+
+// Save writes the record to w as JSON.
+func Save(w io.Writer, r Record) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// Load reads a record from r.
+// The record can be written either as JSON or in the text notation.
+func Load(r io.Reader) (Record, error) {
+	br := bufio.NewReader(r)
+	for {
+		c, _, err := br.ReadRune()
+		if err != nil {
+			return Record{}, err
+		}
+		if unicode.IsSpace(c) {
+			continue
+		}
+		if err := br.UnreadRune(); err != nil {
+			return Record{}, err
+		}
+		if c == '{' {
+			var rec Record
+			if err := json.NewDecoder(br).Decode(&rec); err != nil {
+				return Record{}, err
+			}
+			return rec, nil
+		}
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return Record{}, err
+		}
+		return Parse(line)
+	}
+}
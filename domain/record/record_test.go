@@ -0,0 +1,364 @@
+package record
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/minimax"
+	"tictactoe/domain/game"
+	"tictactoe/domain/player"
+	"tictactoe/domain/rules"
+	"time"
+)
+
+var errUnknownStrategy = errors.New("unknown strategy")
+
+func lookup(name string) (computer.Strategy, error) {
+	if name == "Minimax" {
+		return minimax.NewStrategy(), nil
+	}
+	return nil, errUnknownStrategy
+}
+
+func newGame(moves ...board.Cell) *game.Game {
+	g := game.New(player.MustNew("John"), computer.New(minimax.NewStrategy()))
+	for _, m := range moves {
+		g.MustPlay(m)
+	}
+	return g
+}
+
+func TestFromGame(t *testing.T) {
+	date := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		game *game.Game
+		want Record
+	}{
+		{
+			name: "when game is not finished should return unfinished record",
+			game: newGame(board.MustNewCell(1, 1), board.MustNewCell(0, 0)),
+			want: Record{
+				Date:      date,
+				BoardSize: 3,
+				WinLength: 3,
+				PlayerX:   Player{Name: "John"},
+				PlayerO:   Player{Name: "Computer/Minimax", Strategy: "Minimax"},
+				Moves:     []string{"b2", "a1"},
+				Result:    ResultUnfinished,
+			},
+		},
+		{
+			name: "when X has won should return X wins record",
+			game: newGame(
+				board.MustNewCell(0, 0), board.MustNewCell(1, 0),
+				board.MustNewCell(0, 1), board.MustNewCell(1, 1),
+				board.MustNewCell(0, 2),
+			),
+			want: Record{
+				Date:      date,
+				BoardSize: 3,
+				WinLength: 3,
+				PlayerX:   Player{Name: "John"},
+				PlayerO:   Player{Name: "Computer/Minimax", Strategy: "Minimax"},
+				Moves:     []string{"a1", "a2", "b1", "b2", "c1"},
+				Result:    ResultXWins,
+			},
+		},
+		{
+			name: "when game is over in draw should return draw record",
+			game: newGame(
+				board.MustNewCell(0, 0), board.MustNewCell(1, 1),
+				board.MustNewCell(2, 2), board.MustNewCell(0, 1),
+				board.MustNewCell(2, 1), board.MustNewCell(2, 0),
+				board.MustNewCell(0, 2), board.MustNewCell(1, 2),
+				board.MustNewCell(1, 0),
+			),
+			want: Record{
+				Date:      date,
+				BoardSize: 3,
+				WinLength: 3,
+				PlayerX:   Player{Name: "John"},
+				PlayerO:   Player{Name: "Computer/Minimax", Strategy: "Minimax"},
+				Moves:     []string{"a1", "b2", "c3", "b1", "b3", "a3", "c1", "c2", "a2"},
+				Result:    ResultDraw,
+			},
+		},
+		{
+			name: "when O has resigned should return X wins by resignation record",
+			game: func() *game.Game {
+				g := newGame(board.MustNewCell(1, 1))
+				_ = g.Resign(board.OValue)
+				return g
+			}(),
+			want: Record{
+				Date:        date,
+				BoardSize:   3,
+				WinLength:   3,
+				PlayerX:     Player{Name: "John"},
+				PlayerO:     Player{Name: "Computer/Minimax", Strategy: "Minimax"},
+				Moves:       []string{"b2"},
+				Result:      ResultXWins,
+				Termination: TerminationResignation,
+			},
+		},
+		{
+			name: "when the flag of X has fallen should return O wins on time record",
+			game: func() *game.Game {
+				g := newGame(board.MustNewCell(1, 1), board.MustNewCell(0, 0))
+				_ = g.Flag(board.XValue)
+				return g
+			}(),
+			want: Record{
+				Date:        date,
+				BoardSize:   3,
+				WinLength:   3,
+				PlayerX:     Player{Name: "John"},
+				PlayerO:     Player{Name: "Computer/Minimax", Strategy: "Minimax"},
+				Moves:       []string{"b2", "a1"},
+				Result:      ResultOWins,
+				Termination: TerminationTime,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FromGame(tt.game, date))
+		})
+	}
+}
+
+func TestRecord_Game(t *testing.T) {
+	t.Run("should replay the moves", func(t *testing.T) {
+		g := newGame(board.MustNewCell(1, 1), board.MustNewCell(0, 0))
+		got, err := FromGame(g, time.Now()).Game(lookup)
+		require.NoError(t, err)
+		require.Equal(t, g.GetBoard(), got.GetBoard())
+		player1, player2 := got.Players()
+		require.Equal(t, player.MustNew("John"), player1)
+		require.Equal(t, "Computer/Minimax", player2.Name())
+		require.Len(t, got.Moves(), 2)
+	})
+	t.Run("should replay the moves on sized board", func(t *testing.T) {
+		g := game.NewWithBoard(board.MustNew(5, 4), player.MustNew("John"), player.MustNew("Jane"))
+		g.MustPlay(board.MustNewCell(4, 4))
+		got, err := FromGame(g, time.Now()).Game(lookup)
+		require.NoError(t, err)
+		require.Equal(t, g.GetBoard(), got.GetBoard())
+	})
+	t.Run("should replay the moves of the misere variant", func(t *testing.T) {
+		g := game.NewWithBoard(board.Board{}.WithVariant(board.Misere), player.MustNew("John"), player.MustNew("Jane"))
+		for _, c := range []board.Cell{
+			board.MustNewCell(0, 0), board.MustNewCell(1, 0),
+			board.MustNewCell(0, 1), board.MustNewCell(1, 1),
+			board.MustNewCell(0, 2),
+		} {
+			g.MustPlay(c)
+		}
+		r := FromGame(g, time.Now())
+		require.Equal(t, "misere", r.Variant)
+		require.Equal(t, ResultOWins, r.Result)
+		parsed, err := Parse(r.String())
+		require.NoError(t, err)
+		got, err := parsed.Game(lookup)
+		require.NoError(t, err)
+		require.Equal(t, g.GetBoard(), got.GetBoard())
+	})
+	t.Run("should replay the marked moves of the wild variant", func(t *testing.T) {
+		g := game.NewWithBoard(board.Board{}.WithVariant(board.Wild), player.MustNew("John"), player.MustNew("Jane"))
+		for _, m := range []rules.Move{
+			{Cell: board.MustNewCell(0, 0), Mark: board.OValue},
+			{Cell: board.MustNewCell(1, 1), Mark: board.XValue},
+			{Cell: board.MustNewCell(0, 1), Mark: board.OValue},
+			{Cell: board.MustNewCell(0, 2), Mark: board.OValue},
+		} {
+			require.NoError(t, g.PlayMove(m))
+		}
+		r := FromGame(g, time.Now())
+		require.Equal(t, []string{"Oa1", "Xb2", "Ob1", "Oc1"}, r.Moves)
+		require.Equal(t, ResultOWins, r.Result)
+		parsed, err := Parse(r.String())
+		require.NoError(t, err)
+		got, err := parsed.Game(lookup)
+		require.NoError(t, err)
+		require.Equal(t, g.GetBoard(), got.GetBoard())
+	})
+	t.Run("should replay the game won by resignation or on time", func(t *testing.T) {
+		for _, end := range []func(g *game.Game) error{
+			func(g *game.Game) error { return g.Resign(board.XValue) },
+			func(g *game.Game) error { return g.Flag(board.XValue) },
+		} {
+			g := newGame(board.MustNewCell(1, 1), board.MustNewCell(0, 0))
+			require.NoError(t, end(g))
+			parsed, err := Parse(FromGame(g, time.Now()).String())
+			require.NoError(t, err)
+			got, err := parsed.Game(lookup)
+			require.NoError(t, err)
+			require.True(t, got.IsOver())
+			require.Equal(t, g.Winner().Name(), got.Winner().Name())
+			gotResigned, _ := got.Resigned()
+			wantResigned, _ := g.Resigned()
+			require.Equal(t, wantResigned, gotResigned)
+			gotFlagged, _ := got.Flagged()
+			wantFlagged, _ := g.Flagged()
+			require.Equal(t, wantFlagged, gotFlagged)
+		}
+	})
+	t.Run("should replay the moves on the rectangular gravity board", func(t *testing.T) {
+		g := game.NewWithBoard(board.MustNewRectangular(6, 7, 4).WithVariant(board.Gravity), player.MustNew("John"), player.MustNew("Jane"))
+		require.NoError(t, g.PlayColumn(3))
+		require.NoError(t, g.PlayColumn(3))
+		r := FromGame(g, time.Now())
+		require.Equal(t, 6, r.BoardRows)
+		require.Equal(t, 7, r.BoardSize)
+		require.Equal(t, "gravity", r.Variant)
+		parsed, err := Parse(r.String())
+		require.NoError(t, err)
+		got, err := parsed.Game(lookup)
+		require.NoError(t, err)
+		require.Equal(t, g.GetBoard(), got.GetBoard())
+	})
+	tests := []struct {
+		name    string
+		record  Record
+		wantErr error
+	}{
+		{
+			name: "when variant is unknown should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3, Variant: "suicide",
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Result: ResultUnfinished,
+			},
+			wantErr: board.ErrInvalidVariant,
+		},
+		{
+			name: "when strategy is unknown should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Computer/Wiki", Strategy: "Wiki"},
+				Result: ResultUnfinished,
+			},
+			wantErr: errUnknownStrategy,
+		},
+		{
+			name: "when player name is invalid should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: ""}, PlayerO: Player{Name: "Jane"},
+				Result: ResultUnfinished,
+			},
+			wantErr: player.ErrInvalidPlayer,
+		},
+		{
+			name: "when board size is invalid should return error",
+			record: Record{
+				BoardSize: 30, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Result: ResultUnfinished,
+			},
+			wantErr: board.ErrInvalidSize,
+		},
+		{
+			name: "when cell is played twice should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Moves:  []string{"a1", "a1"},
+				Result: ResultUnfinished,
+			},
+			wantErr: board.ErrCellIsNotEmpty,
+		},
+		{
+			name: "when cell is outside the board should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Moves:  []string{"d1"},
+				Result: ResultUnfinished,
+			},
+			wantErr: board.ErrInvalidCell,
+		},
+		{
+			name: "when result doesn't match the moves should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Moves:  []string{"a1"},
+				Result: ResultXWins,
+			},
+			wantErr: ErrResultMismatch,
+		},
+		{
+			name: "when result is unknown should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Result: Result("2-0"),
+			},
+			wantErr: ErrInvalidResult,
+		},
+		{
+			name: "when termination is unknown should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Result: ResultXWins, Termination: Termination("abandoned"),
+			},
+			wantErr: ErrInvalidTermination,
+		},
+		{
+			name: "when game is terminated in draw should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Moves:  []string{"b2"},
+				Result: ResultDraw, Termination: TerminationResignation,
+			},
+			wantErr: ErrResultMismatch,
+		},
+		{
+			name: "when game is terminated after the end on the board should return error",
+			record: Record{
+				BoardSize: 3, WinLength: 3,
+				PlayerX: Player{Name: "John"}, PlayerO: Player{Name: "Jane"},
+				Moves:  []string{"a1", "a2", "b1", "b2", "c1"},
+				Result: ResultXWins, Termination: TerminationTime,
+			},
+			wantErr: ErrResultMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.record.Game(lookup)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	r := FromGame(
+		newGame(board.MustNewCell(1, 1), board.MustNewCell(0, 0)),
+		time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC),
+	)
+	t.Run("should load saved JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Save(&buf, r))
+		got, err := Load(&buf)
+		require.NoError(t, err)
+		require.Equal(t, r, got)
+	})
+	t.Run("should load text notation", func(t *testing.T) {
+		got, err := Load(strings.NewReader("\n  " + r.String() + "\n"))
+		require.NoError(t, err)
+		require.Equal(t, r, got)
+	})
+	t.Run("when input is empty should return error", func(t *testing.T) {
+		_, err := Load(strings.NewReader(" \n"))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+// Package review reviews finished games: evaluates every played move and finds blunders,
+// the moves that changed the theoretical outcome of the game.
+package review
+
+import (
+	"errors"
+
+	"tictactoe/domain/analysis"
+	"tictactoe/domain/board"
+	"tictactoe/domain/game"
+	"tictactoe/domain/rules"
+)
+
+// Ply is the review of a played move.
+type Ply struct {
+	game.Move
+	// Before is the position the move was played in.
+	Before board.Board
+	// After is the position after the move.
+	After board.Board
+	// Evaluated is false if the position is too big to be analyzed, Played and Best are unknown then.
+	Evaluated bool
+	// Played is the analysis of the played move.
+	Played analysis.Move
+	// Best is the best move of the position.
+	Best analysis.Move
+}
+
+// IsBlunder returns true if the move changed the theoretical outcome for the player,
+// e.g. a drawn position turned into a loss.
+func (p Ply) IsBlunder() bool {
+	return p.Evaluated && p.Played.Outcome < p.Best.Outcome
+}
+
+// Review is the review of a game.
+type Review struct {
+	// Initial is the position the game has started in.
+	Initial board.Board
+	// Plies contains the review of every played move in order.
+	Plies []Ply
+}
+
+// New reviews every move of the game,
+// positions with more than analysis.MaxEmptyCells empty cells are not evaluated.
+func New(g *game.Game) (Review, error) {
+	return FromMoves(g.InitialBoard(), g.Moves())
+}
+
+// FromMoves reviews the moves played from the initial position, see New.
+func FromMoves(initial board.Board, moves []game.Move) (Review, error) {
+	r := Review{Initial: initial}
+	b := initial
+	for _, m := range moves {
+		p := Ply{Move: m, Before: b}
+		after, err := rules.For(b.Variant()).Apply(b, rules.Move{Cell: m.Cell, Mark: m.CellValue})
+		if err != nil {
+			return Review{}, err
+		}
+		p.After = after
+		a, err := analysis.Analyze(b)
+		switch {
+		case err == nil:
+			p.Evaluated = true
+			p.Played, _ = a.MarkedMove(m.Cell, m.CellValue)
+			p.Best = a.Best()
+		case !errors.Is(err, analysis.ErrTooManyEmptyCells):
+			return Review{}, err
+		}
+		r.Plies = append(r.Plies, p)
+		b = after
+	}
+	return r, nil
+}
+
+// Blunders returns the plies of the blunders in order.
+func (r Review) Blunders() []Ply {
+	var blunders []Ply
+	for _, p := range r.Plies {
+		if p.IsBlunder() {
+			blunders = append(blunders, p)
+		}
+	}
+	return blunders
+}
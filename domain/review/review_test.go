@@ -0,0 +1,75 @@
+package review
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/analysis"
+	"tictactoe/domain/board"
+	"tictactoe/domain/game"
+	"tictactoe/domain/player"
+	"tictactoe/domain/rules"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("when a side answers the center on a side should mark the move as the blunder", func(t *testing.T) {
+		g := game.New(player.MustNew("John"), player.MustNew("Jane"))
+		for _, c := range []board.Cell{
+			board.MustNewCell(1, 1),
+			board.MustNewCell(0, 1),
+			board.MustNewCell(0, 0),
+			board.MustNewCell(2, 2),
+			board.MustNewCell(2, 0),
+			board.MustNewCell(1, 0),
+			board.MustNewCell(0, 2),
+		} {
+			g.MustPlay(c)
+		}
+		r, err := New(g)
+		require.NoError(t, err)
+		require.Equal(t, board.Board{}, r.Initial)
+		require.Len(t, r.Plies, 7)
+		require.Equal(t, g.GetBoard(), r.Plies[6].After)
+		require.Equal(t, r.Plies[0].After, r.Plies[1].Before)
+
+		blunders := r.Blunders()
+		require.Len(t, blunders, 1)
+		require.Equal(t, board.MustNewCell(0, 1), blunders[0].Cell)
+		require.Equal(t, analysis.Loss, blunders[0].Played.Outcome)
+		require.Equal(t, analysis.Draw, blunders[0].Best.Outcome)
+		require.Equal(t, board.MustNewCell(0, 0), blunders[0].Best.Cell)
+		for _, p := range r.Plies[2:] {
+			require.True(t, p.Evaluated)
+			if p.CellValue == board.XValue {
+				require.Equal(t, analysis.Win, p.Played.Outcome, p.Cell)
+			} else {
+				require.Equal(t, analysis.Loss, p.Played.Outcome, p.Cell)
+			}
+		}
+	})
+	t.Run("when positions are too big should not evaluate them", func(t *testing.T) {
+		g := game.NewWithBoard(board.MustNew(5, 4), player.MustNew("John"), player.MustNew("Jane"))
+		g.MustPlay(board.MustNewCell(2, 2))
+		g.MustPlay(board.MustNewCell(0, 0))
+		r, err := New(g)
+		require.NoError(t, err)
+		require.Len(t, r.Plies, 2)
+		for _, p := range r.Plies {
+			require.False(t, p.Evaluated)
+			require.False(t, p.IsBlunder())
+		}
+		require.Empty(t, r.Blunders())
+	})
+	t.Run("when the variant is wild should review the placed marks", func(t *testing.T) {
+		g := game.NewWithBoard(board.Board{}.WithVariant(board.Wild), player.MustNew("John"), player.MustNew("Jane"))
+		for _, c := range []board.Cell{board.MustNewCell(0, 0), board.MustNewCell(0, 1), board.MustNewCell(0, 2)} {
+			require.NoError(t, g.PlayMove(rules.Move{Cell: c, Mark: board.OValue}))
+		}
+		r, err := New(g)
+		require.NoError(t, err)
+		require.Len(t, r.Plies, 3)
+		require.Equal(t, g.GetBoard(), r.Plies[2].After)
+		require.Equal(t, analysis.Move{Cell: board.MustNewCell(0, 2), Mark: board.OValue, Outcome: analysis.Win, Plies: 1}, r.Plies[2].Played)
+	})
+}
@@ -0,0 +1,131 @@
+// Package rules describes how a game is played on the board: which moves are legal,
+// how they change the board and how the game ends.
+// The game, the computer players and the search strategies are played against the Rules,
+// so a new variant is a new implementation rather than a fork of them.
+package rules
+
+import (
+	"errors"
+
+	"tictactoe/domain/board"
+)
+
+// ErrWrongMark is returned when the mark of the move is not allowed by the rules.
+var ErrWrongMark = errors.New("wrong mark, the mark is not allowed by the rules")
+
+// Move is a move: the mark placed on the cell.
+type Move struct {
+	Cell board.Cell
+	Mark board.CellValue
+}
+
+// Rules are the rules of a game played on the board.
+// The sides are identified by the cell values: XValue is the first player and OValue is the second one.
+type Rules interface {
+	// LegalMoves returns the moves of the side to move, it returns nil if the game is over.
+	LegalMoves(b board.Board) []Move
+	// Apply returns the board after the move.
+	Apply(b board.Board, m Move) (board.Board, error)
+	// IsTerminal returns true if the game is over.
+	IsTerminal(b board.Board) bool
+	// Result returns the side that has won the game, it returns false if there is no winner yet or it is a draw.
+	Result(b board.Board) (board.CellValue, bool)
+	// SideToMove returns the side that plays the next move.
+	SideToMove(b board.Board) board.CellValue
+}
+
+// Classic are the rules built into the board: the sides alternate placing their own marks
+// and completing a line decides the game. They play boards of any size and the board variants.
+type Classic struct{}
+
+// LegalMoves returns the moves of the side to move in row-major order.
+func (Classic) LegalMoves(b board.Board) []Move {
+	if b.IsCompleted() {
+		return nil
+	}
+	mark := b.CurrentTurnCellValue()
+	cells := b.PlayableCells()
+	moves := make([]Move, 0, len(cells))
+	for _, cell := range cells {
+		moves = append(moves, Move{Cell: cell, Mark: mark})
+	}
+	return moves
+}
+
+// Apply returns the board after the move, the mark must be the mark of the side to move.
+func (Classic) Apply(b board.Board, m Move) (board.Board, error) {
+	if m.Mark != b.CurrentTurnCellValue() {
+		return board.Board{}, ErrWrongMark
+	}
+	return b.SetCellValue(m.Cell)
+}
+
+// IsTerminal returns true if the board is completed.
+func (Classic) IsTerminal(b board.Board) bool {
+	return b.IsCompleted()
+}
+
+// Result returns the winner of the board.
+func (Classic) Result(b board.Board) (board.CellValue, bool) {
+	return b.Winner()
+}
+
+// SideToMove returns the side whose mark is placed next.
+func (Classic) SideToMove(b board.Board) board.CellValue {
+	return b.CurrentTurnCellValue()
+}
+
+// Wild are the rules of wild tic-tac-toe: the sides alternate placing X or O of their choice
+// and the side that completes a line wins. They play boards of the Wild variant.
+type Wild struct {
+	Classic
+}
+
+// LegalMoves returns both marks on every playable cell in row-major order, X first.
+func (Wild) LegalMoves(b board.Board) []Move {
+	if b.IsCompleted() {
+		return nil
+	}
+	cells := b.PlayableCells()
+	moves := make([]Move, 0, 2*len(cells))
+	for _, cell := range cells {
+		moves = append(moves, Move{Cell: cell, Mark: board.XValue}, Move{Cell: cell, Mark: board.OValue})
+	}
+	return moves
+}
+
+// Apply returns the board after the move, the mark may be either X or O.
+func (Wild) Apply(b board.Board, m Move) (board.Board, error) {
+	if m.Mark != board.XValue && m.Mark != board.OValue {
+		return board.Board{}, ErrWrongMark
+	}
+	return b.PlaceCellValue(m.Cell, m.Mark)
+}
+
+// Result returns the side that has completed a line whatever the mark of the line is,
+// the board variant doesn't change the winner.
+func (Wild) Result(b board.Board) (board.CellValue, bool) {
+	return b.WithVariant(board.Wild).Winner()
+}
+
+// For returns the rules of the board variant.
+func For(v board.Variant) Rules {
+	if v == board.Wild {
+		return Wild{}
+	}
+	return Classic{}
+}
+
+// MoveOf returns the move of the side to move on the cell, the mark is the one of the side.
+func MoveOf(r Rules, b board.Board, cell board.Cell) Move {
+	return Move{Cell: cell, Mark: r.SideToMove(b)}
+}
+
+// MustApply is like Rules.Apply but panics if the move is illegal.
+func MustApply(r Rules, b board.Board, m Move) board.Board {
+	b, err := r.Apply(b, m)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
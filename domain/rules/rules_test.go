@@ -0,0 +1,144 @@
+package rules
+
+import (
+	"github.com/stretchr/testify/require"
+	"tictactoe/domain/board"
+
+	"testing"
+)
+
+func TestClassic_LegalMoves(t *testing.T) {
+	tests := []struct {
+		name  string
+		board board.Board
+		want  []Move
+	}{
+		{
+			name: "when the game is not over should return the empty cells with the mark of the side to move",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.OValue, board.XValue},
+				{board.OValue, board.XValue, board.EmptyValue},
+				{board.OValue, board.EmptyValue, board.EmptyValue},
+			}),
+			want: []Move{
+				{Cell: board.MustNewCell(1, 2), Mark: board.XValue},
+				{Cell: board.MustNewCell(2, 1), Mark: board.XValue},
+				{Cell: board.MustNewCell(2, 2), Mark: board.XValue},
+			},
+		},
+		{
+			name: "when the game is over should return nil",
+			board: board.MustNewFromRows([][]board.CellValue{
+				{board.XValue, board.XValue, board.XValue},
+				{board.OValue, board.OValue, board.EmptyValue},
+				{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			}),
+			want: nil,
+		},
+		{
+			name:  "when the variant is gravity should return the lowest empty cells",
+			board: board.MustNew(3, 3).WithVariant(board.Gravity),
+			want: []Move{
+				{Cell: board.MustNewCell(2, 0), Mark: board.XValue},
+				{Cell: board.MustNewCell(2, 1), Mark: board.XValue},
+				{Cell: board.MustNewCell(2, 2), Mark: board.XValue},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Classic{}.LegalMoves(tt.board))
+		})
+	}
+}
+
+func TestClassic_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		move    Move
+		want    board.Board
+		wantErr error
+	}{
+		{
+			name: "when the mark is the side to move should place it",
+			move: Move{Cell: board.MustNewCell(1, 1), Mark: board.XValue},
+			want: board.Board{}.MustSetCellValue(board.MustNewCell(1, 1)),
+		},
+		{
+			name:    "when the mark is not the side to move should return error",
+			move:    Move{Cell: board.MustNewCell(1, 1), Mark: board.OValue},
+			wantErr: ErrWrongMark,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Classic{}.Apply(board.Board{}, tt.move)
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClassic_Result(t *testing.T) {
+	rows := [][]board.CellValue{
+		{board.XValue, board.XValue, board.XValue},
+		{board.OValue, board.OValue, board.EmptyValue},
+		{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+	}
+	t.Run("when a line is completed should be terminal with the winner", func(t *testing.T) {
+		b := board.MustNewFromRows(rows)
+		require.True(t, Classic{}.IsTerminal(b))
+		w, ok := Classic{}.Result(b)
+		require.True(t, ok)
+		require.Equal(t, board.XValue, w)
+	})
+	t.Run("when a line is completed in the misere variant should make the opponent win", func(t *testing.T) {
+		w, ok := Classic{}.Result(board.MustNewFromRows(rows).WithVariant(board.Misere))
+		require.True(t, ok)
+		require.Equal(t, board.OValue, w)
+	})
+	t.Run("when the board is empty should not be terminal and X should move", func(t *testing.T) {
+		require.False(t, Classic{}.IsTerminal(board.Board{}))
+		require.Equal(t, board.XValue, Classic{}.SideToMove(board.Board{}))
+		_, ok := Classic{}.Result(board.Board{})
+		require.False(t, ok)
+	})
+}
+
+func TestWild(t *testing.T) {
+	b := board.Board{}.WithVariant(board.Wild)
+	t.Run("should allow both marks on every empty cell", func(t *testing.T) {
+		moves := Wild{}.LegalMoves(b)
+		require.Len(t, moves, 18)
+		require.Equal(t, Move{Cell: board.MustNewCell(0, 0), Mark: board.OValue}, moves[1])
+	})
+	t.Run("should track the side to move independently of the mark", func(t *testing.T) {
+		got, err := Wild{}.Apply(b, Move{Cell: board.MustNewCell(1, 1), Mark: board.OValue})
+		require.NoError(t, err)
+		require.Equal(t, board.OValue, got.CellValue(board.MustNewCell(1, 1)))
+		require.Equal(t, board.OValue, Wild{}.SideToMove(got))
+		got, err = Wild{}.Apply(got, Move{Cell: board.MustNewCell(0, 0), Mark: board.OValue})
+		require.NoError(t, err)
+		require.Equal(t, board.XValue, Wild{}.SideToMove(got))
+	})
+	t.Run("when the mark is empty should return error", func(t *testing.T) {
+		_, err := Wild{}.Apply(b, Move{Cell: board.MustNewCell(1, 1)})
+		require.ErrorIs(t, err, ErrWrongMark)
+	})
+	t.Run("when a line is completed on a classic board should make the last mover win", func(t *testing.T) {
+		completed := board.MustNewFromRows([][]board.CellValue{
+			{board.OValue, board.OValue, board.OValue},
+			{board.XValue, board.XValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		require.True(t, Wild{}.IsTerminal(completed))
+		w, ok := Wild{}.Result(completed)
+		require.True(t, ok)
+		require.Equal(t, board.XValue, w)
+	})
+}
+
+func TestFor(t *testing.T) {
+	require.Equal(t, Wild{}, For(board.Wild))
+	require.Equal(t, Classic{}, For(board.Misere))
+}
@@ -0,0 +1,159 @@
+// Package simulation plays many games between two computer strategies without user interface.
+package simulation
+
+import (
+	"errors"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/game"
+)
+
+// ErrInvalidGamesCount is returned when the count of games is not positive.
+var ErrInvalidGamesCount = errors.New("games count must be positive")
+
+// StrategyFactory creates a strategy for a single game.
+// The seed must be used by strategies that make random choices, so the results are reproducible.
+type StrategyFactory func(seed int64) computer.Strategy
+
+// Config is the configuration of a simulation.
+type Config struct {
+	// Games is the count of games to play.
+	Games int
+	// Workers is the count of games played in parallel, 0 means the count of CPUs.
+	Workers int
+	// Seed makes the simulation reproducible.
+	Seed int64
+	// RandomOpeningMoves is the count of random moves played before the strategies take over.
+	// Strategies are usually deterministic, so random openings make the games differ.
+	RandomOpeningMoves int
+	// Board is the board to start every game from, the zero value is the classic empty board.
+	Board board.Board
+}
+
+// Outcomes counts the outcomes of games from the point of view of one strategy.
+type Outcomes struct {
+	Wins   int
+	Draws  int
+	Losses int
+}
+
+// Games returns the count of games.
+func (o Outcomes) Games() int {
+	return o.Wins + o.Draws + o.Losses
+}
+
+// add returns the sum of the outcomes.
+func (o Outcomes) add(other Outcomes) Outcomes {
+	return Outcomes{
+		Wins:   o.Wins + other.Wins,
+		Draws:  o.Draws + other.Draws,
+		Losses: o.Losses + other.Losses,
+	}
+}
+
+// Result is the result of a simulation from the point of view of the first strategy.
+// The first strategy plays X in even games and O in odd games.
+type Result struct {
+	// AsX counts the outcomes of games where the first strategy played X.
+	AsX Outcomes
+	// AsO counts the outcomes of games where the first strategy played O.
+	AsO Outcomes
+}
+
+// Total returns the outcomes of all games.
+func (r Result) Total() Outcomes {
+	return r.AsX.add(r.AsO)
+}
+
+// Run plays the games between the strategies created by the factories, alternating colours.
+// Games are played in parallel, but the result depends only on the configuration.
+func Run(first, second StrategyFactory, cfg Config) (Result, error) {
+	if cfg.Games <= 0 {
+		return Result{}, ErrInvalidGamesCount
+	}
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, cfg.Games)
+
+	indexes := make(chan int)
+	results := make(chan Result)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var r Result
+			for i := range indexes {
+				r = r.add(playGame(first, second, cfg, i))
+			}
+			results <- r
+		}()
+	}
+	go func() {
+		for i := 0; i < cfg.Games; i++ {
+			indexes <- i
+		}
+		close(indexes)
+		wg.Wait()
+		close(results)
+	}()
+
+	var total Result
+	for r := range results {
+		total = total.add(r)
+	}
+	return total, nil
+}
+
+// add returns the sum of the results.
+func (r Result) add(other Result) Result {
+	return Result{
+		AsX: r.AsX.add(other.AsX),
+		AsO: r.AsO.add(other.AsO),
+	}
+}
+
+// playGame plays the game with the index and returns its result from the point of view of the first strategy.
+func playGame(first, second StrategyFactory, cfg Config, index int) Result {
+	rnd := rand.New(rand.NewSource(cfg.Seed + int64(index)))
+	firstPlayer := computer.New(first(rnd.Int63()))
+	secondPlayer := computer.New(second(rnd.Int63()))
+	firstIsX := index%2 == 0
+
+	g := game.NewWithBoard(cfg.Board, firstPlayer, secondPlayer)
+	if !firstIsX {
+		g = game.NewWithBoard(cfg.Board, secondPlayer, firstPlayer)
+	}
+	for i := 0; i < cfg.RandomOpeningMoves && !g.IsOver(); i++ {
+		cells := g.GetBoard().PlayableCells()
+		g.MustPlay(cells[rnd.Intn(len(cells))])
+	}
+	for !g.IsOver() {
+		p := g.CurrentTurnPlayer().(computer.Player)
+		g.MustPlayMove(p.GetNextMove(g.GetBoard()))
+	}
+
+	firstCellValue := board.XValue
+	if !firstIsX {
+		firstCellValue = board.OValue
+	}
+	var o Outcomes
+	switch w, ok := g.GetBoard().Winner(); {
+	case !ok:
+		o.Draws++
+	case w == firstCellValue:
+		o.Wins++
+	default:
+		o.Losses++
+	}
+	if firstIsX {
+		return Result{AsX: o}
+	}
+	return Result{AsO: o}
+}
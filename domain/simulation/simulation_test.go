@@ -0,0 +1,81 @@
+package simulation
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"tictactoe/domain/board"
+	"tictactoe/domain/computer"
+	"tictactoe/domain/computer/strategies/minimax"
+	"tictactoe/domain/computer/strategies/wiki"
+)
+
+// firstEmptyCellStrategy plays the first empty cell.
+type firstEmptyCellStrategy struct{}
+
+func (firstEmptyCellStrategy) FindBestCellForNextTurn(b board.Board) board.Cell {
+	return *b.FindFirstEmptyCell()
+}
+
+func (firstEmptyCellStrategy) String() string {
+	return "FirstEmptyCell"
+}
+
+func newMinimax(int64) computer.Strategy {
+	return minimax.NewStrategy()
+}
+
+func newWiki(int64) computer.Strategy {
+	return wiki.NewStrategy()
+}
+
+func newFirstEmptyCell(int64) computer.Strategy {
+	return firstEmptyCellStrategy{}
+}
+
+func TestRun(t *testing.T) {
+	t.Run("when games count is not positive should return error", func(t *testing.T) {
+		_, err := Run(newMinimax, newMinimax, Config{})
+		require.Equal(t, ErrInvalidGamesCount, err)
+	})
+	t.Run("when perfect strategies play should end all games in draw", func(t *testing.T) {
+		got, err := Run(newMinimax, newWiki, Config{Games: 10})
+		require.NoError(t, err)
+		require.Equal(t, Result{
+			AsX: Outcomes{Draws: 5},
+			AsO: Outcomes{Draws: 5},
+		}, got)
+	})
+	t.Run("when perfect strategy plays against weak one should never lose and colours should alternate", func(t *testing.T) {
+		got, err := Run(newMinimax, newFirstEmptyCell, Config{Games: 7, Workers: 3})
+		require.NoError(t, err)
+		require.Equal(t, Result{
+			AsX: Outcomes{Wins: 4},
+			AsO: Outcomes{Wins: 3},
+		}, got)
+		require.Equal(t, Outcomes{Wins: 7}, got.Total())
+	})
+	t.Run("when the variant is wild should play the marks the strategies choose", func(t *testing.T) {
+		got, err := Run(newMinimax, newMinimax, Config{Games: 2, Board: board.Board{}.WithVariant(board.Wild)})
+		require.NoError(t, err)
+		require.Equal(t, Result{
+			AsX: Outcomes{Wins: 1},
+			AsO: Outcomes{Losses: 1},
+		}, got)
+	})
+	t.Run("when seed is the same should return the same result", func(t *testing.T) {
+		cfg := Config{Games: 200, Seed: 42, RandomOpeningMoves: 3}
+		first, err := Run(newWiki, newFirstEmptyCell, cfg)
+		require.NoError(t, err)
+		cfg.Workers = 1
+		second, err := Run(newWiki, newFirstEmptyCell, cfg)
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+		require.Equal(t, 200, first.Total().Games())
+	})
+	t.Run("should play on the configured board", func(t *testing.T) {
+		got, err := Run(newFirstEmptyCell, newFirstEmptyCell, Config{Games: 2, Board: board.MustNew(4, 3)})
+		require.NoError(t, err)
+		// X takes a1, b1, c1 first on the 4x4 board with win length 3.
+		require.Equal(t, Result{AsX: Outcomes{Wins: 1}, AsO: Outcomes{Losses: 1}}, got)
+	})
+}
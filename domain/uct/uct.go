@@ -0,0 +1,154 @@
+// Package uct implements the Monte Carlo tree search with the UCT (Upper Confidence bounds applied to Trees)
+// selection over any game of two sides that place X and O, e.g. tic-tac-toe and Ultimate tic-tac-toe.
+package uct
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"tictactoe/domain/board"
+)
+
+// State is a position of the game, it is immutable: Play returns the next position.
+// S is the type of the state itself and M is the type of its moves.
+type State[S any, M any] interface {
+	// AppendLegalMoves appends the legal moves of the side to move to dst and returns the extended slice,
+	// so playouts can reuse the buffer. It appends nothing if the game is over.
+	AppendLegalMoves(dst []M) []M
+	// Play returns the state after the move of the side to move.
+	Play(m M) S
+	// IsTerminal returns true if the game is over.
+	IsTerminal() bool
+	// Winner returns the winner, EmptyValue while there is no one or in a draw.
+	Winner() board.CellValue
+	// SideToMove returns the side that plays the next move.
+	SideToMove() board.CellValue
+}
+
+// Config configures a search.
+type Config struct {
+	// Iterations limits the search by the count of iterations, zero means no limit.
+	Iterations int
+	// Deadline stops the search, the zero deadline means no limit.
+	// At least one iteration runs whatever the limits are.
+	Deadline time.Time
+	// Exploration is the UCT exploration constant, the higher it is the wider the tree is.
+	Exploration float64
+	// Rand makes the random choices.
+	Rand *rand.Rand
+}
+
+// Search searches the moves of the root state and returns the most visited one.
+// It returns false if there are no moves or the game is over.
+func Search[S State[S, M], M any](c Config, root S, moves []M) (M, bool) {
+	if len(moves) == 0 || root.IsTerminal() {
+		var zero M
+		return zero, false
+	}
+	sr := searcher[S, M]{config: c}
+	rootNode := &node[S, M]{state: root, mover: -root.SideToMove(), untried: moves}
+	for i := 0; ; i++ {
+		if i > 0 && c.Iterations > 0 && i >= c.Iterations {
+			break
+		}
+		if i > 0 && !c.Deadline.IsZero() && time.Now().After(c.Deadline) {
+			break
+		}
+		sr.iterate(rootNode)
+	}
+
+	best := rootNode.children[0]
+	for _, child := range rootNode.children[1:] {
+		if child.visits > best.visits {
+			best = child
+		}
+	}
+	return best.move, true
+}
+
+// searcher holds the state of a single search.
+type searcher[S State[S, M], M any] struct {
+	config Config
+	// moves is the buffer of legal moves of playouts.
+	moves []M
+}
+
+// iterate runs one iteration of the search: selection, expansion, playout and backpropagation.
+func (sr *searcher[S, M]) iterate(root *node[S, M]) {
+	n := root
+	for len(n.untried) == 0 && len(n.children) > 0 {
+		n = n.selectChild(sr.config.Exploration)
+	}
+	if len(n.untried) > 0 {
+		i := sr.config.Rand.Intn(len(n.untried))
+		move := n.untried[i]
+		n.untried[i] = n.untried[len(n.untried)-1]
+		n.untried = n.untried[:len(n.untried)-1]
+		child := newNode(n.state.Play(move), n, move)
+		n.children = append(n.children, child)
+		n = child
+	}
+	winner := sr.playout(n.state)
+	for ; n != nil; n = n.parent {
+		n.visits++
+		switch winner {
+		case n.mover:
+			n.score++
+		case board.EmptyValue:
+			n.score += 0.5
+		}
+	}
+}
+
+// playout plays random moves until the end of the game and returns the winner or EmptyValue in a draw.
+func (sr *searcher[S, M]) playout(s S) board.CellValue {
+	for !s.IsTerminal() {
+		sr.moves = s.AppendLegalMoves(sr.moves[:0])
+		s = s.Play(sr.moves[sr.config.Rand.Intn(len(sr.moves))])
+	}
+	return s.Winner()
+}
+
+// node is a node of the search tree.
+type node[S State[S, M], M any] struct {
+	state S
+	// move is the move that leads to the node.
+	move M
+	// mover is the cell value of the player who made the move.
+	mover    board.CellValue
+	parent   *node[S, M]
+	children []*node[S, M]
+	// untried are the moves not expanded yet.
+	untried []M
+	visits  int
+	// score is the sum of playout results for the mover: 1 for a win, 0.5 for a draw.
+	score float64
+}
+
+// newNode creates a node of the state.
+func newNode[S State[S, M], M any](s S, parent *node[S, M], move M) *node[S, M] {
+	return &node[S, M]{
+		state:   s,
+		move:    move,
+		mover:   -s.SideToMove(),
+		parent:  parent,
+		untried: s.AppendLegalMoves(nil),
+	}
+}
+
+// selectChild returns the child with the highest UCT value.
+func (n *node[S, M]) selectChild(exploration float64) *node[S, M] {
+	logVisits := math.Log(float64(n.visits))
+	var best *node[S, M]
+	bestValue := math.Inf(-1)
+	for _, child := range n.children {
+		value := child.score/float64(child.visits) +
+			exploration*math.Sqrt(logVisits/float64(child.visits))
+		if value > bestValue {
+			best = child
+			bestValue = value
+		}
+	}
+	return best
+}
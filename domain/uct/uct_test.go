@@ -0,0 +1,86 @@
+package uct
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+)
+
+// boardState is the board searched by UCT, the moves are the cells.
+type boardState struct {
+	b board.Board
+}
+
+func (s boardState) AppendLegalMoves(dst []board.Cell) []board.Cell {
+	if s.b.IsCompleted() {
+		return dst
+	}
+	return append(dst, s.b.PlayableCells()...)
+}
+
+func (s boardState) Play(cell board.Cell) boardState {
+	return boardState{b: s.b.MustSetCellValue(cell)}
+}
+
+func (s boardState) IsTerminal() bool {
+	return s.b.IsCompleted()
+}
+
+func (s boardState) Winner() board.CellValue {
+	w, _ := s.b.Winner()
+	return w
+}
+
+func (s boardState) SideToMove() board.CellValue {
+	return s.b.CurrentTurnCellValue()
+}
+
+func TestSearch(t *testing.T) {
+	config := func() Config {
+		return Config{Iterations: 1000, Exploration: math.Sqrt2, Rand: rand.New(rand.NewSource(1))}
+	}
+	t.Run("when the side to move can win should play the winning move", func(t *testing.T) {
+		b := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, board.EmptyValue},
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		got, ok := Search(config(), boardState{b: b}, b.PlayableCells())
+		require.True(t, ok)
+		require.Equal(t, board.MustNewCell(0, 2), got)
+	})
+	t.Run("should choose only from the given moves", func(t *testing.T) {
+		moves := []board.Cell{board.MustNewCell(0, 0), board.MustNewCell(2, 1)}
+		got, ok := Search(config(), boardState{}, moves)
+		require.True(t, ok)
+		require.Contains(t, moves, got)
+	})
+	t.Run("when the deadline passes should stop the search", func(t *testing.T) {
+		b := board.MustNew(15, 5)
+		c := config()
+		c.Iterations, c.Deadline = 0, time.Now().Add(50*time.Millisecond)
+		start := time.Now()
+		got, ok := Search(c, boardState{b: b}, b.PlayableCells())
+		require.True(t, ok)
+		require.Less(t, time.Since(start), time.Second)
+		require.True(t, b.IsEmptyCell(got))
+	})
+	t.Run("when there are no moves should return false", func(t *testing.T) {
+		_, ok := Search(config(), boardState{}, nil)
+		require.False(t, ok)
+	})
+	t.Run("when the game is over should return false", func(t *testing.T) {
+		b := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, board.XValue},
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		_, ok := Search(config(), boardState{b: b}, b.PlayableCells())
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,258 @@
+// Package ultimate implements Ultimate tic-tac-toe: a 3x3 grid of classic boards.
+// The cell a player plays in a board sends the opponent to the board at the same position of the grid.
+// If that board is already won or full, the opponent may play in any board.
+// Winning a board claims its position in the grid, the player who claims three positions in a row wins the game.
+package ultimate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"tictactoe/domain/board"
+)
+
+// Size is the count of boards in a row of the grid and of cells in a row of every board.
+const Size = board.DefaultSize
+
+var (
+	// ErrWrongBoard is returned when a move is played outside the board the previous move has sent to.
+	ErrWrongBoard = errors.New("the move must be played in the board the previous move has sent to")
+	// ErrBoardIsCompleted is returned when a move is played in a board that is already won or full.
+	ErrBoardIsCompleted = errors.New("the board is already won or full")
+)
+
+// Move is a move of Ultimate tic-tac-toe.
+type Move struct {
+	// Board is the position of the board in the grid.
+	Board board.Cell
+	// Cell is the cell inside the board.
+	Cell board.Cell
+}
+
+// String returns the string representation of the move.
+func (m Move) String() string {
+	return fmt.Sprintf("board %d:%d, cell %d:%d",
+		m.Board.RowNumber+1, m.Board.ColumnNumber+1, m.Cell.RowNumber+1, m.Cell.ColumnNumber+1)
+}
+
+// Board is the Ultimate tic-tac-toe grid of classic boards.
+// Board is a value object so is immutable.
+// The zero value is an empty grid with X to move in any board.
+type Board struct {
+	boards [Size][Size]board.Board
+	// next is the position of the board the next move must be played in if hasNext is true.
+	next    board.Cell
+	hasNext bool
+	moves   int
+	// meta, winner and completed are the results of the boards,
+	// they are updated when a move is applied, so reading them doesn't scan the boards.
+	meta      board.Board
+	winner    board.CellValue
+	completed int
+}
+
+// NewFromBoards creates the grid from the boards, the turn follows from the count of marks.
+// The next move must be played in the board at the next position if it is not nil.
+func NewFromBoards(boards [Size][Size]board.Board, next *board.Cell) (Board, error) {
+	b := Board{boards: boards}
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			if boards[i][j].Rows() != Size || boards[i][j].Columns() != Size || boards[i][j].WinLength() != Size {
+				return Board{}, board.ErrInvalidSize
+			}
+			b.moves += boards[i][j].FullCellsCount()
+			if boards[i][j].IsCompleted() {
+				b.completed++
+			}
+		}
+	}
+	b.meta = metaOf(boards)
+	b.winner, _ = b.meta.Winner()
+	if next != nil {
+		if !(board.Board{}).Contains(*next) {
+			return Board{}, board.ErrInvalidCell
+		}
+		b.next, b.hasNext = *next, true
+	}
+	return b, nil
+}
+
+// SubBoard returns the board at the position of the grid.
+func (b Board) SubBoard(position board.Cell) board.Board {
+	return b.boards[position.RowNumber][position.ColumnNumber]
+}
+
+// Meta returns the grid of results: the position of a won board holds its winner.
+func (b Board) Meta() board.Board {
+	return b.meta
+}
+
+// metaOf returns the grid of results of the boards.
+func metaOf(boards [Size][Size]board.Board) board.Board {
+	rows := make([][]board.CellValue, Size)
+	for i := range rows {
+		rows[i] = make([]board.CellValue, Size)
+		for j := range rows[i] {
+			rows[i][j], _ = boards[i][j].Winner()
+		}
+	}
+	return board.MustNewFromRows(rows)
+}
+
+// CurrentTurnCellValue returns the current turn cell value.
+func (b Board) CurrentTurnCellValue() board.CellValue {
+	if b.moves%2 == 0 {
+		return board.XValue
+	}
+	return board.OValue
+}
+
+// OpponentCellValue returns the opponent cell value.
+func (b Board) OpponentCellValue() board.CellValue {
+	return -b.CurrentTurnCellValue()
+}
+
+// ActiveBoards returns the positions of the boards the next move may be played in.
+func (b Board) ActiveBoards() []board.Cell {
+	if b.IsCompleted() {
+		return nil
+	}
+	if b.hasNext && !b.SubBoard(b.next).IsCompleted() {
+		return []board.Cell{b.next}
+	}
+	var positions []board.Cell
+	for i := 0; i < Size; i++ {
+		for j := 0; j < Size; j++ {
+			if !b.boards[i][j].IsCompleted() {
+				positions = append(positions, board.Cell{RowNumber: i, ColumnNumber: j})
+			}
+		}
+	}
+	return positions
+}
+
+// IsActiveBoard returns true if the next move may be played in the board at the position.
+func (b Board) IsActiveBoard(position board.Cell) bool {
+	for _, p := range b.ActiveBoards() {
+		if p == position {
+			return true
+		}
+	}
+	return false
+}
+
+// LegalMoves returns the moves the current player may play.
+func (b Board) LegalMoves() []Move {
+	var moves []Move
+	for _, position := range b.ActiveBoards() {
+		for _, cell := range b.SubBoard(position).EmptyCells() {
+			moves = append(moves, Move{Board: position, Cell: cell})
+		}
+	}
+	return moves
+}
+
+// Play returns a new board with the move of the current player.
+func (b Board) Play(m Move) (Board, error) {
+	if b.IsCompleted() {
+		return Board{}, board.ErrGameIsOver
+	}
+	if !(board.Board{}).Contains(m.Board) {
+		return Board{}, board.ErrInvalidCell
+	}
+	sub := b.SubBoard(m.Board)
+	if sub.IsCompleted() {
+		return Board{}, ErrBoardIsCompleted
+	}
+	if !b.IsActiveBoard(m.Board) {
+		return Board{}, ErrWrongBoard
+	}
+	sub, err := sub.PlaceCellValue(m.Cell, b.CurrentTurnCellValue())
+	if err != nil {
+		return Board{}, err
+	}
+	r := b
+	r.boards[m.Board.RowNumber][m.Board.ColumnNumber] = sub
+	r.next, r.hasNext = m.Cell, true
+	r.moves++
+	if sub.IsCompleted() {
+		r.completed++
+	}
+	if _, ok := sub.Winner(); ok {
+		r.meta = metaOf(r.boards)
+		r.winner, _ = r.meta.Winner()
+	}
+	return r, nil
+}
+
+// MustPlay is like Play but panics if the move is illegal.
+func (b Board) MustPlay(m Move) Board {
+	r, err := b.Play(m)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Winner returns the winner if there is one, otherwise EmptyValue.
+// It also returns true if there is a winner, otherwise false.
+func (b Board) Winner() (board.CellValue, bool) {
+	return b.winner, b.winner != board.EmptyValue
+}
+
+// IsCompleted returns true if the game is completed:
+// there is a winner or every board is won or full.
+func (b Board) IsCompleted() bool {
+	return b.winner != board.EmptyValue || b.completed == Size*Size
+}
+
+// MovesCount returns the count of played moves.
+func (b Board) MovesCount() int {
+	return b.moves
+}
+
+// String returns the string representation of the grid.
+func (b Board) String() string {
+	return b.Sprint(nil)
+}
+
+// Sprint returns the string representation of the grid with the cursor shown as [-].
+// Cells of the boards the next move may be played in are shown as :-: unless the game is completed.
+func (b Board) Sprint(cursor *Move) string {
+	var rows []string
+	for i := 0; i < Size; i++ {
+		if i > 0 {
+			rows = append(rows, strings.Repeat("-", 3*Size*Size+2*(Size-1)))
+		}
+		for r := 0; r < Size; r++ {
+			boards := make([]string, Size)
+			for j := 0; j < Size; j++ {
+				position := board.Cell{RowNumber: i, ColumnNumber: j}
+				active := b.IsActiveBoard(position)
+				var cells strings.Builder
+				for c := 0; c < Size; c++ {
+					cell := board.Cell{RowNumber: r, ColumnNumber: c}
+					v := b.SubBoard(position).CellValue(cell).String()
+					switch {
+					case cursor != nil && cursor.Board == position && cursor.Cell == cell:
+						cells.WriteString("[" + v + "]")
+					case active:
+						cells.WriteString(":" + v + ":")
+					default:
+						cells.WriteString(" " + v + " ")
+					}
+				}
+				boards[j] = cells.String()
+			}
+			rows = append(rows, strings.Join(boards, "||"))
+		}
+	}
+	return strings.Join(rows, "\n") + "\n"
+}
+
+// Strategy is the interface of computer strategies of Ultimate tic-tac-toe.
+type Strategy interface {
+	FindBestMove(b Board) Move
+	String() string
+}
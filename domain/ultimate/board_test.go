@@ -0,0 +1,167 @@
+package ultimate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+)
+
+// wonBy returns a classic board won by the value.
+func wonBy(v board.CellValue) board.Board {
+	e := board.EmptyValue
+	return board.MustNewFromRows([][]board.CellValue{
+		{v, v, v},
+		{-v, -v, e},
+		{e, e, e},
+	})
+}
+
+func TestBoard_Play(t *testing.T) {
+	t.Run("when the grid is empty should allow every cell to X", func(t *testing.T) {
+		var b Board
+		require.Equal(t, board.XValue, b.CurrentTurnCellValue())
+		require.Len(t, b.ActiveBoards(), 9)
+		require.Len(t, b.LegalMoves(), 81)
+	})
+	t.Run("when a move is played should send the opponent to the board at the cell position", func(t *testing.T) {
+		b, err := Board{}.Play(Move{Board: board.MustNewCell(1, 1), Cell: board.MustNewCell(0, 2)})
+		require.NoError(t, err)
+		require.Equal(t, board.OValue, b.CurrentTurnCellValue())
+		require.Equal(t, board.XValue, b.SubBoard(board.MustNewCell(1, 1)).CellValue(board.MustNewCell(0, 2)))
+		require.Equal(t, []board.Cell{board.MustNewCell(0, 2)}, b.ActiveBoards())
+		require.Len(t, b.LegalMoves(), 9)
+		require.Equal(t, 1, b.MovesCount())
+
+		_, err = b.Play(Move{Board: board.MustNewCell(1, 1), Cell: board.MustNewCell(0, 0)})
+		require.Equal(t, ErrWrongBoard, err)
+
+		b = b.MustPlay(Move{Board: board.MustNewCell(0, 2), Cell: board.MustNewCell(1, 1)})
+		_, err = b.Play(Move{Board: board.MustNewCell(1, 1), Cell: board.MustNewCell(0, 2)})
+		require.Equal(t, board.ErrCellIsNotEmpty, err)
+	})
+	t.Run("when the opponent is sent to a completed board should allow any other board", func(t *testing.T) {
+		var boards [Size][Size]board.Board
+		boards[0][0] = wonBy(board.XValue)
+		next := board.MustNewCell(0, 0)
+		b, err := NewFromBoards(boards, &next)
+		require.NoError(t, err)
+		require.Len(t, b.ActiveBoards(), 8)
+		require.NotContains(t, b.ActiveBoards(), board.MustNewCell(0, 0))
+
+		_, err = b.Play(Move{Board: board.MustNewCell(0, 0), Cell: board.MustNewCell(2, 2)})
+		require.Equal(t, ErrBoardIsCompleted, err)
+		_, err = b.Play(Move{Board: board.MustNewCell(2, 2), Cell: board.MustNewCell(2, 2)})
+		require.NoError(t, err)
+	})
+	t.Run("when the move is outside the grid should return error", func(t *testing.T) {
+		_, err := Board{}.Play(Move{Board: board.MustNewCell(3, 0), Cell: board.MustNewCell(0, 0)})
+		require.Equal(t, board.ErrInvalidCell, err)
+	})
+}
+
+func TestNewFromBoards(t *testing.T) {
+	t.Run("should derive the turn from the count of marks and restrict the next board", func(t *testing.T) {
+		var boards [Size][Size]board.Board
+		boards[0][0] = wonBy(board.XValue)
+		next := board.MustNewCell(1, 2)
+		b, err := NewFromBoards(boards, &next)
+		require.NoError(t, err)
+		require.Equal(t, 5, b.MovesCount())
+		require.Equal(t, board.OValue, b.CurrentTurnCellValue())
+		require.Equal(t, []board.Cell{next}, b.ActiveBoards())
+	})
+	t.Run("when a board is not classic should return error", func(t *testing.T) {
+		var boards [Size][Size]board.Board
+		boards[1][1] = board.MustNew(4, 3)
+		_, err := NewFromBoards(boards, nil)
+		require.Equal(t, board.ErrInvalidSize, err)
+	})
+	t.Run("when the next board is outside the grid should return error", func(t *testing.T) {
+		next := board.MustNewCell(0, 3)
+		_, err := NewFromBoards([Size][Size]board.Board{}, &next)
+		require.Equal(t, board.ErrInvalidCell, err)
+	})
+}
+
+func TestBoard_Winner(t *testing.T) {
+	t.Run("when a player has won three boards in a row should be the winner", func(t *testing.T) {
+		var boards [Size][Size]board.Board
+		boards[0][0] = wonBy(board.OValue)
+		boards[1][1] = wonBy(board.OValue)
+		boards[2][2] = wonBy(board.OValue)
+		boards[0][1] = wonBy(board.XValue)
+		b, err := NewFromBoards(boards, nil)
+		require.NoError(t, err)
+		w, ok := b.Winner()
+		require.True(t, ok)
+		require.Equal(t, board.OValue, w)
+		require.True(t, b.IsCompleted())
+		require.Empty(t, b.LegalMoves())
+		_, err = b.Play(Move{Board: board.MustNewCell(1, 0), Cell: board.MustNewCell(0, 0)})
+		require.Equal(t, board.ErrGameIsOver, err)
+	})
+	t.Run("when boards are won by both players without a row should not have the winner", func(t *testing.T) {
+		var boards [Size][Size]board.Board
+		boards[0][0] = wonBy(board.OValue)
+		boards[0][1] = wonBy(board.XValue)
+		boards[0][2] = wonBy(board.OValue)
+		b, err := NewFromBoards(boards, nil)
+		require.NoError(t, err)
+		_, ok := b.Winner()
+		require.False(t, ok)
+		require.False(t, b.IsCompleted())
+		require.Equal(t, board.MustNewFromRows([][]board.CellValue{
+			{board.OValue, board.XValue, board.OValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		}), b.Meta())
+	})
+}
+
+func TestBoard_IsCompleted(t *testing.T) {
+	t.Run("when a move wins the board and the row of boards should update the results", func(t *testing.T) {
+		e := board.EmptyValue
+		var boards [Size][Size]board.Board
+		boards[0][0] = board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, e},
+			{board.OValue, board.OValue, e},
+			{e, e, e},
+		})
+		boards[1][1] = wonBy(board.XValue)
+		boards[2][2] = wonBy(board.XValue)
+		next := board.MustNewCell(0, 0)
+		b, err := NewFromBoards(boards, &next)
+		require.NoError(t, err)
+		_, ok := b.Winner()
+		require.False(t, ok)
+
+		b = b.MustPlay(Move{Board: board.MustNewCell(0, 0), Cell: board.MustNewCell(0, 2)})
+		w, ok := b.Winner()
+		require.True(t, ok)
+		require.Equal(t, board.XValue, w)
+		require.True(t, b.IsCompleted())
+		require.Equal(t, board.XValue, b.Meta().CellValue(board.MustNewCell(0, 0)))
+	})
+}
+
+func TestBoard_Sprint(t *testing.T) {
+	t.Run("should mark the cursor and the cells of the active board", func(t *testing.T) {
+		b := Board{}.MustPlay(Move{Board: board.MustNewCell(0, 0), Cell: board.MustNewCell(0, 1)})
+		want := "" +
+			" -  X  - ||:-::-::-:|| -  -  - \n" +
+			" -  -  - ||:-::-::-:|| -  -  - \n" +
+			" -  -  - ||:-::-:[-]|| -  -  - \n" +
+			"-------------------------------\n" +
+			" -  -  - || -  -  - || -  -  - \n" +
+			" -  -  - || -  -  - || -  -  - \n" +
+			" -  -  - || -  -  - || -  -  - \n" +
+			"-------------------------------\n" +
+			" -  -  - || -  -  - || -  -  - \n" +
+			" -  -  - || -  -  - || -  -  - \n" +
+			" -  -  - || -  -  - || -  -  - \n"
+		got := b.Sprint(&Move{Board: board.MustNewCell(0, 1), Cell: board.MustNewCell(2, 2)})
+		require.Equal(t, want, got)
+	})
+}
@@ -0,0 +1,119 @@
+// Package mcts implements the Monte Carlo tree search strategy of Ultimate tic-tac-toe.
+package mcts
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/uct"
+	"tictactoe/domain/ultimate"
+)
+
+const (
+	defaultIterations  = 5000
+	defaultExploration = math.Sqrt2
+)
+
+// Strategy is a computer strategy that implements the Monte Carlo tree search
+// with the UCT (Upper Confidence bounds applied to Trees) selection.
+// Strategy is not safe for concurrent use.
+type Strategy struct {
+	iterations  int
+	timeBudget  time.Duration
+	exploration float64
+	rnd         *rand.Rand
+}
+
+// Option configures the Strategy.
+type Option func(*Strategy)
+
+// WithIterations limits the search by the count of iterations.
+func WithIterations(iterations int) Option {
+	return func(s *Strategy) {
+		s.iterations = iterations
+	}
+}
+
+// WithTimeBudget limits the search by time.
+func WithTimeBudget(budget time.Duration) Option {
+	return func(s *Strategy) {
+		s.timeBudget = budget
+	}
+}
+
+// WithSeed makes the random choices reproducible.
+func WithSeed(seed int64) Option {
+	return func(s *Strategy) {
+		s.rnd = rand.New(rand.NewSource(seed))
+	}
+}
+
+// NewStrategy returns a new Strategy.
+// The search stops when any of the iterations and time budgets is exhausted.
+// Without budgets the search is limited by 5000 iterations.
+// Without a seed the random choices differ from run to run.
+func NewStrategy(opts ...Option) *Strategy {
+	s := &Strategy{
+		exploration: defaultExploration,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.iterations == 0 && s.timeBudget == 0 {
+		s.iterations = defaultIterations
+	}
+	if s.rnd == nil {
+		s.rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return s
+}
+
+// String returns the string representation of the Strategy.
+func (s *Strategy) String() string {
+	return "MCTS"
+}
+
+// FindBestMove finds the best move for the current player: the most visited one.
+// It returns the zero move if the game is over.
+func (s *Strategy) FindBestMove(b ultimate.Board) ultimate.Move {
+	var deadline time.Time
+	if s.timeBudget > 0 {
+		deadline = time.Now().Add(s.timeBudget)
+	}
+	c := uct.Config{Iterations: s.iterations, Deadline: deadline, Exploration: s.exploration, Rand: s.rnd}
+	best, _ := uct.Search(c, position{b: b}, b.LegalMoves())
+	return best
+}
+
+// position is the board searched by UCT.
+type position struct {
+	b ultimate.Board
+}
+
+// AppendLegalMoves appends the legal moves of the board.
+func (p position) AppendLegalMoves(dst []ultimate.Move) []ultimate.Move {
+	return append(dst, p.b.LegalMoves()...)
+}
+
+// Play returns the position after the move.
+func (p position) Play(m ultimate.Move) position {
+	return position{b: p.b.MustPlay(m)}
+}
+
+// IsTerminal returns true if the game is completed.
+func (p position) IsTerminal() bool {
+	return p.b.IsCompleted()
+}
+
+// Winner returns the winner of the game.
+func (p position) Winner() board.CellValue {
+	w, _ := p.b.Winner()
+	return w
+}
+
+// SideToMove returns the current turn cell value.
+func (p position) SideToMove() board.CellValue {
+	return p.b.CurrentTurnCellValue()
+}
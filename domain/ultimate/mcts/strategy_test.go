@@ -0,0 +1,61 @@
+package mcts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"tictactoe/domain/board"
+	"tictactoe/domain/ultimate"
+)
+
+func TestStrategy_FindBestMove(t *testing.T) {
+	t.Run("when the player can win the game should win it", func(t *testing.T) {
+		x, o, e := board.XValue, board.OValue, board.EmptyValue
+		won := board.MustNewFromRows([][]board.CellValue{
+			{x, x, x},
+			{o, o, e},
+			{e, e, e},
+		})
+		var boards [ultimate.Size][ultimate.Size]board.Board
+		boards[0][0], boards[0][1] = won, won
+		boards[0][2] = board.MustNewFromRows([][]board.CellValue{
+			{x, e, e},
+			{o, x, e},
+			{o, e, e},
+		})
+		next := board.MustNewCell(0, 2)
+		b, err := ultimate.NewFromBoards(boards, &next)
+		require.NoError(t, err)
+		require.Equal(t, board.XValue, b.CurrentTurnCellValue())
+		got := NewStrategy(WithSeed(1), WithIterations(2000)).FindBestMove(b)
+		require.Equal(t, ultimate.Move{Board: next, Cell: board.MustNewCell(2, 2)}, got)
+	})
+	t.Run("when the game is over should return the zero move", func(t *testing.T) {
+		won := board.MustNewFromRows([][]board.CellValue{
+			{board.XValue, board.XValue, board.XValue},
+			{board.OValue, board.OValue, board.EmptyValue},
+			{board.EmptyValue, board.EmptyValue, board.EmptyValue},
+		})
+		var boards [ultimate.Size][ultimate.Size]board.Board
+		boards[0][0], boards[0][1], boards[0][2] = won, won, won
+		b, err := ultimate.NewFromBoards(boards, nil)
+		require.NoError(t, err)
+		require.True(t, b.IsCompleted())
+		require.Equal(t, ultimate.Move{}, NewStrategy(WithSeed(1), WithIterations(50)).FindBestMove(b))
+	})
+	t.Run("when two strategies play a game should play only legal moves until the end", func(t *testing.T) {
+		x := NewStrategy(WithSeed(1), WithIterations(50))
+		o := NewStrategy(WithSeed(2), WithIterations(50))
+		var b ultimate.Board
+		for !b.IsCompleted() {
+			s := x
+			if b.CurrentTurnCellValue() == board.OValue {
+				s = o
+			}
+			var err error
+			b, err = b.Play(s.FindBestMove(b))
+			require.NoError(t, err)
+		}
+	})
+}